@@ -22,9 +22,10 @@ type void struct{}
 var member void
 
 type aclCache struct {
-	acls  []*sarama.ResourceAcls
-	mutex sync.RWMutex
-	valid bool
+	acls   []*sarama.ResourceAcls
+	byName map[string][]*sarama.ResourceAcls
+	mutex  sync.RWMutex
+	valid  bool
 }
 
 type aclDeletionQueue struct {
@@ -374,6 +375,58 @@ func (c *Client) CanAlterReplicationFactor() bool {
 	return ok1 && ok2
 }
 
+// SupportsIncrementalAlterConfigs reports whether every broker accepts
+// IncrementalAlterConfigs, added in Kafka 2.3
+func (c *Client) SupportsIncrementalAlterConfigs() bool {
+	_, ok := c.supportedAPIs[incrementalAlterConfigsKey] // https://kafka.apache.org/protocol#The_Messages_IncrementalAlterConfigs
+
+	return ok
+}
+
+// SupportsScramAdmin reports whether SCRAM credentials can be described and
+// altered through the admin API, added in Kafka 2.7
+func (c *Client) SupportsScramAdmin() bool {
+	_, ok1 := c.supportedAPIs[50] // https://kafka.apache.org/protocol#The_Messages_DescribeUserScramCredentials
+	_, ok2 := c.supportedAPIs[51] // https://kafka.apache.org/protocol#The_Messages_AlterUserScramCredentials
+
+	return ok1 && ok2
+}
+
+// SupportsClientQuotas reports whether client quotas can be described and
+// altered through the admin API, added in Kafka 2.6
+func (c *Client) SupportsClientQuotas() bool {
+	_, ok1 := c.supportedAPIs[48] // https://kafka.apache.org/protocol#The_Messages_DescribeClientQuotas
+	_, ok2 := c.supportedAPIs[49] // https://kafka.apache.org/protocol#The_Messages_AlterClientQuotas
+
+	return ok1 && ok2
+}
+
+// Capabilities are the API versions every broker supports and the features
+// they enable
+type Capabilities struct {
+	// APIVersions maps each API key to the highest version all brokers accept
+	APIVersions                     map[int]int
+	CanAlterReplicationFactor       bool
+	SupportsIncrementalAlterConfigs bool
+	SupportsScramAdmin              bool
+	SupportsClientQuotas            bool
+}
+
+func (c *Client) Capabilities() Capabilities {
+	apiVersions := make(map[int]int, len(c.supportedAPIs))
+	for apiKey, version := range c.supportedAPIs {
+		apiVersions[apiKey] = version
+	}
+
+	return Capabilities{
+		APIVersions:                     apiVersions,
+		CanAlterReplicationFactor:       c.CanAlterReplicationFactor(),
+		SupportsIncrementalAlterConfigs: c.SupportsIncrementalAlterConfigs(),
+		SupportsScramAdmin:              c.SupportsScramAdmin(),
+		SupportsClientQuotas:            c.SupportsClientQuotas(),
+	}
+}
+
 func (c *Client) AlterReplicationFactor(t Topic) error {
 	log.Printf("[DEBUG] Refreshing metadata for topic '%s'", t.Name)
 	if err := c.client.RefreshMetadata(t.Name); err != nil {
@@ -119,3 +119,37 @@ func Test_ClientAPIVersion(t *testing.T) {
 		t.Errorf("Got %d, expected %d", maxVersion, 1)
 	}
 }
+
+func Test_ClientCapabilities(t *testing.T) {
+	// Kafka 2.6: client quotas, but no SCRAM admin API
+	client := &Client{supportedAPIs: map[int]int{32: 3, 44: 1, 45: 0, 46: 0, 48: 0, 49: 0}}
+	capabilities := client.Capabilities()
+
+	if !capabilities.CanAlterReplicationFactor {
+		t.Error("Expected the replication factor to be alterable")
+	}
+	if !capabilities.SupportsIncrementalAlterConfigs {
+		t.Error("Expected IncrementalAlterConfigs to be supported")
+	}
+	if !capabilities.SupportsClientQuotas {
+		t.Error("Expected client quotas to be supported")
+	}
+	if capabilities.SupportsScramAdmin {
+		t.Error("Expected the SCRAM admin API not to be supported")
+	}
+	if capabilities.APIVersions[32] != 3 {
+		t.Errorf("Got %d, expected %d", capabilities.APIVersions[32], 3)
+	}
+
+	// The versions are a copy, so callers cannot change what the client uses
+	capabilities.APIVersions[32] = 0
+	if client.supportedAPIs[32] != 3 {
+		t.Errorf("Got %d, expected %d", client.supportedAPIs[32], 3)
+	}
+
+	// Only one half of an API pair is not enough
+	client.supportedAPIs = map[int]int{50: 0}
+	if client.SupportsScramAdmin() {
+		t.Error("Expected the SCRAM admin API not to be supported without AlterUserScramCredentials")
+	}
+}
@@ -0,0 +1,97 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func kafkaACLAuthorizationDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceACLAuthorizationRead,
+		Description: "Evaluates whether Kafka's ACL authorizer would allow a principal to perform an operation on a resource, based on the ACLs in the cluster.",
+		Schema: map[string]*schema.Schema{
+			"acl_principal": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The principal performing the operation, e.g. User:Alice.",
+			},
+			"acl_host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "*",
+				Description: "The address the principal connects from. The default, *, only matches ACLs that apply to every host.",
+			},
+			"acl_operation": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiagFunc(validateACLEnum(aclAuthorizedOperations)),
+				Description:      "The operation being performed.",
+			},
+			"resource_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiagFunc(validateACLEnum(aclResourceTypes)),
+				Description:      "The type of the resource.",
+			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the resource. Use kafka-cluster for the Cluster resource.",
+			},
+			"allow_everyone_if_no_acl_found": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Mirror the broker's allow.everyone.if.no.acl.found setting, allowing any operation on resources without ACLs.",
+			},
+			"allowed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the operation would be allowed.",
+			},
+			"reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Why the operation is allowed or not: " + authorizationAllowed + ", " + authorizationDenied + ", " + authorizationNoAllow + " or " + authorizationNoACLs + ".",
+			},
+			"deciding_acls": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The ACLs that decided the result: the matching Deny ACLs if the operation is denied, otherwise the matching Allow ACLs.",
+				Elem:        aclDataElem(),
+			},
+		},
+	}
+}
+
+func dataSourceACLAuthorizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*LazyClient)
+
+	req := AuthorizationRequest{
+		Principal:    d.Get("acl_principal").(string),
+		Host:         d.Get("acl_host").(string),
+		Operation:    d.Get("acl_operation").(string),
+		ResourceType: d.Get("resource_type").(string),
+		ResourceName: d.Get("resource_name").(string),
+	}
+
+	acls, err := client.ListACLs()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := authorize(flattenResourceACLs(acls), req, d.Get("allow_everyone_if_no_acl_found").(bool))
+
+	errSet := errSetter{d: d}
+	errSet.Set("allowed", result.Allowed)
+	errSet.Set("reason", result.Reason)
+	errSet.Set("deciding_acls", flattenACLsData(result.DecidingACLs))
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	d.SetId(req.String())
+	return nil
+}
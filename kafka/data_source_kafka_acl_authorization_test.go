@@ -0,0 +1,77 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAcc_ACLAuthorizationDataSource(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	topicName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testDataSourceKafkaACLAuthorization, topicName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("data.kafka_acl_authorization.consume", "allowed", "true"),
+					r.TestCheckResourceAttr("data.kafka_acl_authorization.consume", "reason", "allowed"),
+					r.TestCheckResourceAttr("data.kafka_acl_authorization.consume", "deciding_acls.#", "1"),
+					r.TestCheckResourceAttr("data.kafka_acl_authorization.consume", "deciding_acls.0.resource_pattern_type_filter", "Prefixed"),
+					r.TestCheckResourceAttr("data.kafka_acl_authorization.produce", "allowed", "false"),
+					r.TestCheckResourceAttr("data.kafka_acl_authorization.produce", "reason", "denied"),
+					r.TestCheckResourceAttr("data.kafka_acl_authorization.produce", "deciding_acls.0.acl_permission_type", "Deny"),
+				),
+			},
+		},
+	})
+}
+
+const testDataSourceKafkaACLAuthorization = `
+resource "kafka_acl" "read" {
+  resource_name                = "%[1]s"
+  resource_type                = "Topic"
+  resource_pattern_type_filter = "Prefixed"
+  acl_principal                = "User:Alice"
+  acl_host                     = "*"
+  acl_operation                = "Read"
+  acl_permission_type          = "Allow"
+}
+
+resource "kafka_acl" "deny_write" {
+  resource_name       = "%[1]s-events"
+  resource_type       = "Topic"
+  acl_principal       = "User:Alice"
+  acl_host            = "*"
+  acl_operation       = "Write"
+  acl_permission_type = "Deny"
+}
+
+data "kafka_acl_authorization" "consume" {
+  acl_principal = "User:Alice"
+  acl_operation = "Read"
+  resource_type = "Topic"
+  resource_name = "%[1]s-events"
+
+  depends_on = [kafka_acl.read, kafka_acl.deny_write]
+}
+
+data "kafka_acl_authorization" "produce" {
+  acl_principal = "User:Alice"
+  acl_operation = "Write"
+  resource_type = "Topic"
+  resource_name = "%[1]s-events"
+
+  depends_on = [kafka_acl.read, kafka_acl.deny_write]
+}
+`
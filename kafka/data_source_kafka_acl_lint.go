@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func kafkaACLLintDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceACLLintRead,
+		Description: "Analyses the ACLs in the cluster for redundant, shadowed and overlapping bindings.",
+		Schema: map[string]*schema.Schema{
+			"acl_principal": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only report findings for ACLs of this principal. ACLs of every principal are still considered as the cause of a finding.",
+			},
+			"kinds": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Only report these kinds of findings. Defaults to every kind.",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateDiagFunc(validation.StringInSlice(aclFindingKinds, false)),
+				},
+			},
+			"findings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The findings, sorted by kind and ACL.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The kind of finding: covered, redundant_operation, shadowed or overlapping_prefix.",
+						},
+						"acl_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ACL the finding is about, in the kafka_acl import ID format.",
+						},
+						"related_acl_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ACL causing the finding, in the kafka_acl import ID format.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A description of the finding.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceACLLintRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*LazyClient)
+	principal := d.Get("acl_principal").(string)
+
+	kinds := aclFindingKinds
+	if v, ok := d.GetOk("kinds"); ok {
+		kinds = []string{}
+		for _, k := range v.(*schema.Set).List() {
+			kinds = append(kinds, k.(string))
+		}
+	}
+
+	acls, err := client.ListACLs()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	findings := []interface{}{}
+	for _, f := range lintACLs(flattenResourceACLs(acls)) {
+		if principal != "" && f.ACL.ACL.Principal != principal {
+			continue
+		}
+		if !slices.Contains(kinds, f.Kind) {
+			continue
+		}
+		findings = append(findings, map[string]interface{}{
+			"kind":           f.Kind,
+			"acl_id":         f.ACL.String(),
+			"related_acl_id": f.Related.String(),
+			"message":        f.Message,
+		})
+	}
+
+	if err := d.Set("findings", findings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprint(len(findings)))
+	return nil
+}
@@ -0,0 +1,64 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAcc_ACLLintDataSource(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal := fmt.Sprintf("User:lint-%s", u)
+	topicName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testDataSourceKafkaACLLint, principal, topicName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("data.kafka_acl_lint.test", "findings.#", "1"),
+					r.TestCheckResourceAttr("data.kafka_acl_lint.test", "findings.0.kind", "covered"),
+					r.TestCheckResourceAttr("data.kafka_acl_lint.test", "findings.0.acl_id", fmt.Sprintf("%s|*|Read|Allow|Topic|%s-events|Literal", principal, topicName)),
+					r.TestCheckResourceAttr("data.kafka_acl_lint.test", "findings.0.related_acl_id", fmt.Sprintf("%s|*|Read|Allow|Topic|%s|Prefixed", principal, topicName)),
+				),
+			},
+		},
+	})
+}
+
+const testDataSourceKafkaACLLint = `
+resource "kafka_acl" "prefixed" {
+  resource_name                = "%[2]s"
+  resource_type                = "Topic"
+  resource_pattern_type_filter = "Prefixed"
+  acl_principal                = "%[1]s"
+  acl_host                     = "*"
+  acl_operation                = "Read"
+  acl_permission_type          = "Allow"
+}
+
+resource "kafka_acl" "literal" {
+  resource_name       = "%[2]s-events"
+  resource_type       = "Topic"
+  acl_principal       = "%[1]s"
+  acl_host            = "*"
+  acl_operation       = "Read"
+  acl_permission_type = "Allow"
+}
+
+data "kafka_acl_lint" "test" {
+  acl_principal = "%[1]s"
+  kinds         = ["covered"]
+
+  depends_on = [kafka_acl.prefixed, kafka_acl.literal]
+}
+`
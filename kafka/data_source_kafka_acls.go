@@ -0,0 +1,162 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func kafkaACLsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceACLsRead,
+		Description: "Provides a list of the ACLs in the cluster matching the given filters.",
+		Schema: map[string]*schema.Schema{
+			"resource_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return ACLs for this resource name.",
+			},
+			"resource_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "Any",
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice(append([]string{"Any"}, aclResourceTypes...), false)),
+				Description:      "Only return ACLs for this resource type.",
+			},
+			"resource_pattern_type_filter": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "Any",
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{"Any", "Match", "Literal", "Prefixed"}, false)),
+				Description:      "How to match resource_name. Any returns ACLs of every pattern type with exactly this name, Match returns every literal, prefixed and wildcard ACL that applies to the resource, Literal and Prefixed only return ACLs of that pattern type.",
+			},
+			"acl_principal": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return ACLs for this principal, e.g. User:Alice.",
+			},
+			"acl_host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return ACLs for this host.",
+			},
+			"acl_operation": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "Any",
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice(append([]string{"Any"}, aclOperations...), false)),
+				Description:      "Only return ACLs for this operation.",
+			},
+			"acl_permission_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "Any",
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice(append([]string{"Any"}, aclPermissionTypes...), false)),
+				Description:      "Only return ACLs with this permission type.",
+			},
+			"acls": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "A list of the matching ACLs.",
+				Elem:        aclDataElem(),
+			},
+		},
+	}
+}
+
+// aclDataElem is the schema of an ACL in the ACL data sources
+func aclDataElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"resource_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the resource.",
+			},
+			"resource_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the resource.",
+			},
+			"resource_pattern_type_filter": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The pattern type of the resource name.",
+			},
+			"acl_principal": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The principal that is allowed or denied.",
+			},
+			"acl_host": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The host the principal is allowed or denied from.",
+			},
+			"acl_operation": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The operation that is allowed or denied.",
+			},
+			"acl_permission_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the operation is allowed or denied.",
+			},
+		},
+	}
+}
+
+func dataSourceACLsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*LazyClient)
+
+	filter := StringlyTypedACL{
+		ACL: ACL{
+			Principal:      d.Get("acl_principal").(string),
+			Host:           d.Get("acl_host").(string),
+			Operation:      d.Get("acl_operation").(string),
+			PermissionType: d.Get("acl_permission_type").(string),
+		},
+		Resource: Resource{
+			Type:              d.Get("resource_type").(string),
+			Name:              d.Get("resource_name").(string),
+			PatternTypeFilter: d.Get("resource_pattern_type_filter").(string),
+		},
+	}
+
+	acls, err := client.FilterACLs(filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list := flattenACLsData(acls)
+	if err := d.Set("acls", list); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(filter.String())
+	return diags
+}
+
+func flattenACLsData(acls []StringlyTypedACL) []any {
+	sort.Slice(acls, func(i, j int) bool {
+		return acls[i].String() < acls[j].String()
+	})
+
+	list := make([]any, 0, len(acls))
+	for _, a := range acls {
+		list = append(list, map[string]any{
+			"resource_name":                a.Name,
+			"resource_type":                a.Type,
+			"resource_pattern_type_filter": a.PatternTypeFilter,
+			"acl_principal":                a.ACL.Principal,
+			"acl_host":                     a.ACL.Host,
+			"acl_operation":                a.ACL.Operation,
+			"acl_permission_type":          a.ACL.PermissionType,
+		})
+	}
+	return list
+}
@@ -0,0 +1,82 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAcc_ACLsDataSource(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	topicName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testDataSourceKafkaACLs, topicName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("data.kafka_acls.literal", "acls.#", "1"),
+					r.TestCheckResourceAttr("data.kafka_acls.literal", "acls.0.acl_principal", "User:Alice"),
+					r.TestCheckResourceAttr("data.kafka_acls.literal", "acls.0.acl_operation", "Read"),
+					r.TestCheckResourceAttr("data.kafka_acls.literal", "acls.0.resource_pattern_type_filter", "Literal"),
+					r.TestCheckResourceAttr("data.kafka_acls.match", "acls.#", "2"),
+					r.TestCheckResourceAttr("data.kafka_acls.match", "acls.0.resource_pattern_type_filter", "Literal"),
+					r.TestCheckResourceAttr("data.kafka_acls.match", "acls.1.resource_pattern_type_filter", "Prefixed"),
+					r.TestCheckResourceAttr("data.kafka_acls.match_longer_name", "acls.#", "1"),
+					r.TestCheckResourceAttr("data.kafka_acls.match_longer_name", "acls.0.acl_principal", "User:Bob"),
+				),
+			},
+		},
+	})
+}
+
+const testDataSourceKafkaACLs = `
+resource "kafka_acl" "literal" {
+  resource_name       = "%[1]s"
+  resource_type       = "Topic"
+  acl_principal       = "User:Alice"
+  acl_host            = "*"
+  acl_operation       = "Read"
+  acl_permission_type = "Allow"
+}
+
+resource "kafka_acl" "prefixed" {
+  resource_name                = "%[1]s"
+  resource_type                = "Topic"
+  resource_pattern_type_filter = "Prefixed"
+  acl_principal                = "User:Bob"
+  acl_host                     = "*"
+  acl_operation                = "Write"
+  acl_permission_type          = "Allow"
+}
+
+data "kafka_acls" "literal" {
+  resource_type                = "Topic"
+  resource_name                = "%[1]s"
+  resource_pattern_type_filter = "Literal"
+  depends_on                   = [kafka_acl.literal, kafka_acl.prefixed]
+}
+
+data "kafka_acls" "match" {
+  resource_type                = "Topic"
+  resource_name                = "%[1]s"
+  resource_pattern_type_filter = "Match"
+  depends_on                   = [kafka_acl.literal, kafka_acl.prefixed]
+}
+
+data "kafka_acls" "match_longer_name" {
+  resource_type                = "Topic"
+  resource_name                = "%[1]s-suffix"
+  resource_pattern_type_filter = "Match"
+  depends_on                   = [kafka_acl.literal, kafka_acl.prefixed]
+}
+`
@@ -0,0 +1,74 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func kafkaBrokersDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBrokersRead,
+		Description: "Provides the id, address and rack of every broker in the Kafka cluster.",
+		Schema: map[string]*schema.Schema{
+			"brokers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The brokers, sorted by id.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The id of the broker.",
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The host the broker advertises on the provider's listener.",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The port the broker advertises on the provider's listener.",
+						},
+						"rack": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The rack of the broker, empty when broker.rack is not set.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBrokersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*LazyClient)
+	cluster, err := client.DescribeCluster()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("brokers", flattenClusterBrokers(cluster.Brokers)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(clusterDataSourceID(cluster))
+	return nil
+}
+
+func flattenClusterBrokers(brokers []ClusterBroker) []interface{} {
+	list := make([]interface{}, 0, len(brokers))
+	for _, b := range brokers {
+		list = append(list, map[string]interface{}{
+			"id":   int(b.ID),
+			"host": b.Host,
+			"port": int(b.Port),
+			"rack": b.Rack,
+		})
+	}
+	return list
+}
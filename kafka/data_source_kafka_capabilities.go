@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func kafkaCapabilitiesDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCapabilitiesRead,
+		Description: "Provides the API versions every broker in the cluster supports, and the provider features they enable.",
+		Schema: map[string]*schema.Schema{
+			"api_versions": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The highest version of each API all brokers accept, keyed by API key, e.g. 44 for IncrementalAlterConfigs. APIs with no version every broker accepts are left out.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"can_alter_replication_factor": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the replication_factor of a kafka_topic can be changed in place (Kafka 2.4+).",
+			},
+			"supports_incremental_alter_configs": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether configs can be changed one at a time with IncrementalAlterConfigs (Kafka 2.3+), as kafka_broker_config and kafka_broker_logger require.",
+			},
+			"supports_scram_admin": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether SCRAM credentials can be managed through the admin API (Kafka 2.7+), as kafka_user_scram_credential requires.",
+			},
+			"supports_client_quotas": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether client quotas can be managed through the admin API (Kafka 2.6+), as kafka_quota requires.",
+			},
+		},
+	}
+}
+
+func dataSourceCapabilitiesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*LazyClient)
+	capabilities, err := client.Capabilities()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	apiVersions := make(map[string]int, len(capabilities.APIVersions))
+	for apiKey, version := range capabilities.APIVersions {
+		apiVersions[strconv.Itoa(apiKey)] = version
+	}
+
+	errSet := errSetter{d: d}
+	errSet.Set("api_versions", apiVersions)
+	errSet.Set("can_alter_replication_factor", capabilities.CanAlterReplicationFactor)
+	errSet.Set("supports_incremental_alter_configs", capabilities.SupportsIncrementalAlterConfigs)
+	errSet.Set("supports_scram_admin", capabilities.SupportsScramAdmin)
+	errSet.Set("supports_client_quotas", capabilities.SupportsClientQuotas)
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	// A fixed ID, since each provider talks to a single cluster and reading
+	// the cluster ID would cost a Metadata request for every topic
+	d.SetId("kafka-capabilities")
+	return nil
+}
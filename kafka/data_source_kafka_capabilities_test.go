@@ -0,0 +1,35 @@
+package kafka
+
+import (
+	"testing"
+
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAcc_CapabilitiesDataSource(t *testing.T) {
+	t.Parallel()
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, testDataSourceKafkaCapabilities),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("data.kafka_capabilities.test", "id", "kafka-capabilities"),
+					r.TestCheckResourceAttrSet("data.kafka_capabilities.test", "api_versions.3"),
+					r.TestCheckResourceAttr("data.kafka_capabilities.test", "can_alter_replication_factor", "true"),
+					r.TestCheckResourceAttr("data.kafka_capabilities.test", "supports_incremental_alter_configs", "true"),
+					r.TestCheckResourceAttr("data.kafka_capabilities.test", "supports_scram_admin", "true"),
+					r.TestCheckResourceAttr("data.kafka_capabilities.test", "supports_client_quotas", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testDataSourceKafkaCapabilities = `
+data "kafka_capabilities" "test" {
+}
+`
@@ -0,0 +1,73 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func kafkaClusterDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClusterRead,
+		Description: "Provides the ID, controller and broker layout of the Kafka cluster.",
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the cluster. Empty on clusters older than Kafka 0.10.1.",
+			},
+			"controller_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The id of the controller broker. On KRaft clusters this is a random broker, as the controller quorum is not exposed to clients.",
+			},
+			"broker_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The ids of every broker, sorted.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"racks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The distinct racks of the brokers, sorted. Empty when broker.rack is not set.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*LazyClient)
+	cluster, err := client.DescribeCluster()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	brokerIDs := make([]int, 0, len(cluster.Brokers))
+	for _, b := range cluster.Brokers {
+		brokerIDs = append(brokerIDs, int(b.ID))
+	}
+
+	errSet := errSetter{d: d}
+	errSet.Set("cluster_id", cluster.ID)
+	errSet.Set("controller_id", int(cluster.ControllerID))
+	errSet.Set("broker_ids", brokerIDs)
+	errSet.Set("racks", cluster.Racks())
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	d.SetId(clusterDataSourceID(cluster))
+	return nil
+}
+
+// clusterDataSourceID is the cluster ID, or a fixed ID for clusters too old
+// to report one
+func clusterDataSourceID(cluster *Cluster) string {
+	if cluster.ID == "" {
+		return "kafka-cluster"
+	}
+	return cluster.ID
+}
@@ -0,0 +1,42 @@
+package kafka
+
+import (
+	"testing"
+
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAcc_ClusterDataSources(t *testing.T) {
+	t.Parallel()
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, testDataSourceKafkaCluster),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttrSet("data.kafka_cluster.test", "cluster_id"),
+					r.TestCheckResourceAttrSet("data.kafka_cluster.test", "controller_id"),
+					r.TestCheckResourceAttr("data.kafka_cluster.test", "broker_ids.#", "3"),
+					r.TestCheckResourceAttr("data.kafka_cluster.test", "broker_ids.0", "1"),
+					r.TestCheckResourceAttr("data.kafka_cluster.test", "broker_ids.2", "3"),
+					r.TestCheckResourceAttrPair("data.kafka_brokers.test", "id", "data.kafka_cluster.test", "cluster_id"),
+					r.TestCheckResourceAttr("data.kafka_brokers.test", "brokers.#", "3"),
+					r.TestCheckResourceAttr("data.kafka_brokers.test", "brokers.0.id", "1"),
+					r.TestCheckResourceAttrSet("data.kafka_brokers.test", "brokers.0.host"),
+					r.TestCheckResourceAttrSet("data.kafka_brokers.test", "brokers.0.port"),
+				),
+			},
+		},
+	})
+}
+
+const testDataSourceKafkaCluster = `
+data "kafka_cluster" "test" {
+}
+
+data "kafka_brokers" "test" {
+}
+`
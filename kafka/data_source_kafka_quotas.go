@@ -0,0 +1,131 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func kafkaQuotasDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceQuotasRead,
+		Description: "Provides a list of the client quotas in the cluster, optionally filtered by entity.",
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only return quotas whose entity matches every filter. Without filters all quotas are returned.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"entity_type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{"client-id", "user", "ip"}, false)),
+							Description:      "The type of the entity component to filter on (client-id, user, ip)",
+						},
+						"match_type": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          quotaMatchAny,
+							ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{quotaMatchAny, quotaMatchExact, quotaMatchDefault}, false)),
+							Description:      "How to match the entity name: any (every entity of this type), exact (entity_name only) or default (the default entity only)",
+						},
+						"entity_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The entity name to match when match_type is exact",
+						},
+					},
+				},
+			},
+			"list": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "A list containing all the matching quotas.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The quota ID, in the format accepted by kafka_quota import.",
+						},
+						"entity": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The components of the quota entity.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The type of the entity component.",
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the entity component, empty for the default entity.",
+									},
+								},
+							},
+						},
+						"config": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "A map of the quota values.",
+							Elem:        schema.TypeFloat,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceQuotasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*LazyClient)
+
+	filters := []QuotaFilter{}
+	for _, f := range d.Get("filter").([]interface{}) {
+		m, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		filters = append(filters, QuotaFilter{
+			EntityType: m["entity_type"].(string),
+			MatchType:  m["match_type"].(string),
+			Name:       m["entity_name"].(string),
+		})
+	}
+
+	quotas, err := client.DescribeQuotas(filters)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list := flattenQuotasData(quotas)
+	if err := d.Set("list", list); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprint(len(list)))
+	return diags
+}
+
+func flattenQuotasData(quotas []Quota) []any {
+	list := make([]any, 0, len(quotas))
+	for _, q := range quotas {
+		config := make(map[string]any, len(q.Ops))
+		for _, op := range q.Ops {
+			config[op.Key] = op.Value
+		}
+		list = append(list, map[string]any{
+			"id":     q.ID(),
+			"entity": flattenQuotaEntity(q.Entity),
+			"config": config,
+		})
+	}
+	return list
+}
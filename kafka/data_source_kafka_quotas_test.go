@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAcc_QuotasDataSource(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	userName := fmt.Sprintf("quota-user-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testDataSourceKafkaQuotas, userName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("data.kafka_quotas.user", "list.#", "2"),
+					r.TestCheckResourceAttr("data.kafka_quotas.user", "list.0.id", fmt.Sprintf("%s|user", userName)),
+					r.TestCheckResourceAttr("data.kafka_quotas.user", "list.0.config.producer_byte_rate", "2500000"),
+					r.TestCheckResourceAttr("data.kafka_quotas.user", "list.1.id", fmt.Sprintf("%s|user|app|client-id", userName)),
+					r.TestCheckResourceAttr("data.kafka_quotas.user", "list.1.entity.1.type", "client-id"),
+					r.TestCheckResourceAttr("data.kafka_quotas.user", "list.1.entity.1.name", "app"),
+					testDatasourceQuotasContains("data.kafka_quotas.all", fmt.Sprintf("%s|user|app|client-id", userName)),
+				),
+			},
+		},
+	})
+}
+
+func testDatasourceQuotasContains(name string, id string) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState := s.Modules[0].Resources[name]
+		if resourceState == nil {
+			return fmt.Errorf("resource not found in state")
+		}
+		attrs := resourceState.Primary.Attributes
+
+		count, err := strconv.Atoi(attrs["list.#"])
+		if err != nil {
+			return err
+		}
+		for i := 0; i < count; i++ {
+			if attrs[fmt.Sprintf("list.%d.id", i)] == id {
+				return nil
+			}
+		}
+		return fmt.Errorf("quota %s not found in %s", id, name)
+	}
+}
+
+const testDataSourceKafkaQuotas = `
+resource "kafka_quota" "user" {
+  entity_name = "%[1]s"
+  entity_type = "user"
+  config = {
+    "producer_byte_rate" = "2500000"
+  }
+}
+
+resource "kafka_quota" "user_client" {
+  entity {
+    type = "user"
+    name = "%[1]s"
+  }
+  entity {
+    type = "client-id"
+    name = "app"
+  }
+  config = {
+    "consumer_byte_rate" = "4000000"
+  }
+}
+
+data "kafka_quotas" "user" {
+  filter {
+    entity_type = "user"
+    match_type  = "exact"
+    entity_name = "%[1]s"
+  }
+  depends_on = [kafka_quota.user, kafka_quota.user_client]
+}
+
+data "kafka_quotas" "all" {
+  depends_on = [kafka_quota.user, kafka_quota.user_client]
+}
+`
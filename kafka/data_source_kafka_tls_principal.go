@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func kafkaTLSPrincipalDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTLSPrincipalRead,
+		Description: "Derives the principal Kafka assigns to a client authenticating with a TLS certificate, emulating the broker's ssl.principal.mapping.rules. No connection to the cluster is made.",
+		Schema: map[string]*schema.Schema{
+			"certificate_pem": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The PEM encoded client certificate. Only the first certificate is used.",
+			},
+			"principal_mapping_rules": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "DEFAULT",
+				Description: "The broker's ssl.principal.mapping.rules, e.g. RULE:^CN=(.*?),OU=ServiceUsers.*$/$1/L,DEFAULT. Patterns use Go regular expression syntax.",
+			},
+			"distinguished_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The subject of the certificate in the RFC 2253 form the mapping rules are applied to.",
+			},
+			"principal": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The principal for use in kafka_acl, e.g. User:CN=alice,O=Example.",
+			},
+		},
+	}
+}
+
+func dataSourceTLSPrincipalRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	block, _ := pem.Decode([]byte(d.Get("certificate_pem").(string)))
+	if block == nil {
+		return diag.FromErr(errors.New("certificate_pem does not contain a PEM block"))
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse certificate: %w", err))
+	}
+
+	dn, err := certificateDistinguishedName(cert)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rules, err := parsePrincipalMappingRules(d.Get("principal_mapping_rules").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name, err := mapPrincipalName(dn, rules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	principal := userPrincipalPrefix + name
+
+	errSet := errSetter{d: d}
+	errSet.Set("distinguished_name", dn)
+	errSet.Set("principal", principal)
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	d.SetId(principal)
+	return nil
+}
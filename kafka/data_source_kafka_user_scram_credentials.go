@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func kafkaUserScramCredentialsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUserScramCredentialsRead,
+		Description: "Lists every user with SCRAM credentials in the cluster, with their mechanisms and iteration counts.",
+		Schema: map[string]*schema.Schema{
+			"usernames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the users, sorted.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The users, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the user.",
+						},
+						"mechanism": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The SCRAM mechanisms of the user.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The SCRAM mechanism (SCRAM-SHA-256, SCRAM-SHA-512).",
+									},
+									"iterations": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The number of SCRAM iterations of the credential.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUserScramCredentialsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*LazyClient)
+
+	userScramCredentials, err := client.ListUserScramCredentials()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	usernames, users := flattenUserScramCredentials(userScramCredentials)
+
+	errSet := errSetter{d: d}
+	errSet.Set("usernames", usernames)
+	errSet.Set("users", users)
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	d.SetId(fmt.Sprint(len(usernames)))
+	return nil
+}
+
+// flattenUserScramCredentials groups credentials sorted by user into one
+// element per user
+func flattenUserScramCredentials(userScramCredentials []UserScramCredential) ([]string, []interface{}) {
+	usernames := []string{}
+	users := []interface{}{}
+	for _, userScramCredential := range userScramCredentials {
+		mechanism := map[string]interface{}{
+			"name":       userScramCredential.Mechanism.String(),
+			"iterations": int(userScramCredential.Iterations),
+		}
+
+		if n := len(usernames); n > 0 && usernames[n-1] == userScramCredential.Name {
+			user := users[n-1].(map[string]interface{})
+			user["mechanism"] = append(user["mechanism"].([]interface{}), mechanism)
+			continue
+		}
+
+		usernames = append(usernames, userScramCredential.Name)
+		users = append(users, map[string]interface{}{
+			"username":  userScramCredential.Name,
+			"mechanism": []interface{}{mechanism},
+		})
+	}
+	return usernames, users
+}
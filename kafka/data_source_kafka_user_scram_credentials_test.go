@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/IBM/sarama"
+	uuid "github.com/hashicorp/go-uuid"
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAcc_UserScramCredentialsDataSource(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	username := fmt.Sprintf("test-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testDataSourceKafkaUserScramCredentials, username)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckTypeSetElemAttr("data.kafka_user_scram_credentials.test", "usernames.*", username),
+					r.TestCheckTypeSetElemNestedAttrs("data.kafka_user_scram_credentials.test", "users.*", map[string]string{
+						"username":               username,
+						"mechanism.#":            "1",
+						"mechanism.0.name":       "SCRAM-SHA-512",
+						"mechanism.0.iterations": "8192",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenUserScramCredentials(t *testing.T) {
+	usernames, users := flattenUserScramCredentials([]UserScramCredential{
+		{Name: "alice", Mechanism: sarama.SCRAM_MECHANISM_SHA_256, Iterations: 4096},
+		{Name: "alice", Mechanism: sarama.SCRAM_MECHANISM_SHA_512, Iterations: 8192},
+		{Name: "bob", Mechanism: sarama.SCRAM_MECHANISM_SHA_512, Iterations: 4096},
+	})
+
+	if fmt.Sprint(usernames) != "[alice bob]" {
+		t.Fatalf("expected usernames [alice bob], got %v", usernames)
+	}
+	alice := users[0].(map[string]interface{})["mechanism"].([]interface{})
+	if len(alice) != 2 || alice[1].(map[string]interface{})["name"] != "SCRAM-SHA-512" || alice[1].(map[string]interface{})["iterations"] != 8192 {
+		t.Errorf("expected alice to have both mechanisms, got %v", alice)
+	}
+	bob := users[1].(map[string]interface{})["mechanism"].([]interface{})
+	if len(bob) != 1 {
+		t.Errorf("expected bob to have one mechanism, got %v", bob)
+	}
+}
+
+const testDataSourceKafkaUserScramCredentials = `
+resource "kafka_user_scram_credential" "test" {
+  username         = "%s"
+  scram_mechanism  = "SCRAM-SHA-512"
+  scram_iterations = 8192
+  password         = "test"
+}
+
+data "kafka_user_scram_credentials" "test" {
+  depends_on = [kafka_user_scram_credential.test]
+}
+`
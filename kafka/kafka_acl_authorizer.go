@@ -0,0 +1,126 @@
+package kafka
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	aclWildcardPrincipal = "User:*"
+	aclWildcardHost      = "*"
+	aclWildcardResource  = "*"
+
+	authorizationAllowed = "allowed"
+	authorizationDenied  = "denied"
+	authorizationNoAllow = "no_matching_allow"
+	authorizationNoACLs  = "no_acls_for_resource"
+)
+
+// aclAuthorizedOperations are the operations a request can be authorized for.
+// All only appears in ACLs.
+var aclAuthorizedOperations = []string{"Read", "Write", "Create", "Delete", "Alter", "Describe", "ClusterAction", "DescribeConfigs", "AlterConfigs", "IdempotentWrite"}
+
+// AuthorizationRequest is an action to evaluate against the ACLs of the
+// cluster
+type AuthorizationRequest struct {
+	Principal    string
+	Host         string
+	Operation    string
+	ResourceType string
+	ResourceName string
+}
+
+func (r AuthorizationRequest) String() string {
+	return strings.Join([]string{r.Principal, r.Host, r.Operation, r.ResourceType, r.ResourceName}, "|")
+}
+
+// AuthorizationResult is the decision for an AuthorizationRequest, along with
+// the ACLs that decided it
+type AuthorizationResult struct {
+	Allowed      bool
+	Reason       string
+	DecidingACLs []StringlyTypedACL
+}
+
+// authorize emulates Kafka's AclAuthorizer. Of the ACLs bound to the resource
+// through a literal, prefixed or wildcard pattern, those for the principal or
+// User:* and for the host or * apply. A matching Deny wins over any Allow, and
+// an Allow of Read, Write, Delete or Alter also allows Describe, as an Allow of
+// AlterConfigs allows DescribeConfigs. Super users are not taken into account.
+func authorize(acls []StringlyTypedACL, req AuthorizationRequest, allowIfNoACLs bool) AuthorizationResult {
+	resourceACLs := []StringlyTypedACL{}
+	for _, a := range acls {
+		if aclMatchesResource(a, req.ResourceType, req.ResourceName) {
+			resourceACLs = append(resourceACLs, a)
+		}
+	}
+
+	// Like allow.everyone.if.no.acl.found on the broker
+	if len(resourceACLs) == 0 {
+		return AuthorizationResult{Allowed: allowIfNoACLs, Reason: authorizationNoACLs, DecidingACLs: []StringlyTypedACL{}}
+	}
+
+	denies := matchingACLs(resourceACLs, req, "Deny", []string{req.Operation})
+	if len(denies) > 0 {
+		return AuthorizationResult{Allowed: false, Reason: authorizationDenied, DecidingACLs: denies}
+	}
+
+	allows := matchingACLs(resourceACLs, req, "Allow", impliedAllowOperations(req.Operation))
+	if len(allows) > 0 {
+		return AuthorizationResult{Allowed: true, Reason: authorizationAllowed, DecidingACLs: allows}
+	}
+
+	return AuthorizationResult{Allowed: false, Reason: authorizationNoAllow, DecidingACLs: []StringlyTypedACL{}}
+}
+
+// impliedAllowOperations lists the operations whose Allow ACLs grant op
+func impliedAllowOperations(op string) []string {
+	switch op {
+	case "Describe":
+		return []string{"Describe", "Read", "Write", "Delete", "Alter"}
+	case "DescribeConfigs":
+		return []string{"DescribeConfigs", "AlterConfigs"}
+	}
+	return []string{op}
+}
+
+func matchingACLs(acls []StringlyTypedACL, req AuthorizationRequest, permissionType string, ops []string) []StringlyTypedACL {
+	matching := []StringlyTypedACL{}
+	for _, a := range acls {
+		if a.ACL.PermissionType != permissionType {
+			continue
+		}
+		if a.ACL.Principal != req.Principal && a.ACL.Principal != aclWildcardPrincipal {
+			continue
+		}
+		if a.ACL.Host != req.Host && a.ACL.Host != aclWildcardHost {
+			continue
+		}
+		if a.ACL.Operation != "All" && !slices.Contains(ops, a.ACL.Operation) {
+			continue
+		}
+		matching = append(matching, a)
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].String() < matching[j].String()
+	})
+	return matching
+}
+
+// aclMatchesResource reports whether the ACL's resource pattern applies to the
+// named resource
+func aclMatchesResource(a StringlyTypedACL, resourceType, name string) bool {
+	if a.Type != resourceType {
+		return false
+	}
+
+	switch a.PatternTypeFilter {
+	case "Literal":
+		return a.Name == name || a.Name == aclWildcardResource
+	case "Prefixed":
+		return strings.HasPrefix(name, a.Name)
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package kafka
+
+import (
+	"testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	acl := func(principal, host, op, permission, resourceType, name, pattern string) StringlyTypedACL {
+		return StringlyTypedACL{
+			ACL:      ACL{Principal: principal, Host: host, Operation: op, PermissionType: permission},
+			Resource: Resource{Type: resourceType, Name: name, PatternTypeFilter: pattern},
+		}
+	}
+
+	acls := []StringlyTypedACL{
+		acl("User:Alice", "*", "Read", "Allow", "Topic", "payments", "Literal"),
+		acl("User:Alice", "*", "Write", "Allow", "Topic", "orders-", "Prefixed"),
+		acl("User:*", "*", "Describe", "Allow", "Topic", "*", "Literal"),
+		acl("User:Bob", "*", "All", "Allow", "Topic", "payments", "Literal"),
+		acl("User:Bob", "10.0.0.1", "Write", "Deny", "Topic", "payments", "Literal"),
+		acl("User:Carol", "10.0.0.2", "Read", "Allow", "Group", "billing", "Literal"),
+		acl("User:Dave", "*", "AlterConfigs", "Allow", "Cluster", "kafka-cluster", "Literal"),
+		acl("User:*", "*", "All", "Deny", "Topic", "secret-", "Prefixed"),
+		acl("User:Erin", "*", "Read", "Allow", "Topic", "secret-sauce", "Literal"),
+	}
+
+	tests := []struct {
+		name    string
+		req     AuthorizationRequest
+		allowed bool
+		reason  string
+		acls    []string
+	}{
+		{
+			name:    "literal allow",
+			req:     AuthorizationRequest{Principal: "User:Alice", Host: "10.0.0.9", Operation: "Read", ResourceType: "Topic", ResourceName: "payments"},
+			allowed: true,
+			reason:  authorizationAllowed,
+			acls:    []string{"User:Alice|*|Read|Allow|Topic|payments|Literal"},
+		},
+		{
+			name:    "prefixed allow",
+			req:     AuthorizationRequest{Principal: "User:Alice", Host: "*", Operation: "Write", ResourceType: "Topic", ResourceName: "orders-eu"},
+			allowed: true,
+			reason:  authorizationAllowed,
+			acls:    []string{"User:Alice|*|Write|Allow|Topic|orders-|Prefixed"},
+		},
+		{
+			name:    "wildcard resource and principal",
+			req:     AuthorizationRequest{Principal: "User:Zed", Host: "*", Operation: "Describe", ResourceType: "Topic", ResourceName: "anything"},
+			allowed: true,
+			reason:  authorizationAllowed,
+			acls:    []string{"User:*|*|Describe|Allow|Topic|*|Literal"},
+		},
+		{
+			name:    "read implies describe",
+			req:     AuthorizationRequest{Principal: "User:Alice", Host: "*", Operation: "Describe", ResourceType: "Topic", ResourceName: "payments"},
+			allowed: true,
+			reason:  authorizationAllowed,
+			acls: []string{
+				"User:*|*|Describe|Allow|Topic|*|Literal",
+				"User:Alice|*|Read|Allow|Topic|payments|Literal",
+			},
+		},
+		{
+			name:    "alter configs implies describe configs",
+			req:     AuthorizationRequest{Principal: "User:Dave", Host: "*", Operation: "DescribeConfigs", ResourceType: "Cluster", ResourceName: "kafka-cluster"},
+			allowed: true,
+			reason:  authorizationAllowed,
+			acls:    []string{"User:Dave|*|AlterConfigs|Allow|Cluster|kafka-cluster|Literal"},
+		},
+		{
+			name:    "write does not imply read",
+			req:     AuthorizationRequest{Principal: "User:Alice", Host: "*", Operation: "Read", ResourceType: "Topic", ResourceName: "orders-eu"},
+			allowed: false,
+			reason:  authorizationNoAllow,
+		},
+		{
+			name:    "deny overrides all",
+			req:     AuthorizationRequest{Principal: "User:Bob", Host: "10.0.0.1", Operation: "Write", ResourceType: "Topic", ResourceName: "payments"},
+			allowed: false,
+			reason:  authorizationDenied,
+			acls:    []string{"User:Bob|10.0.0.1|Write|Deny|Topic|payments|Literal"},
+		},
+		{
+			name:    "deny only applies to its host",
+			req:     AuthorizationRequest{Principal: "User:Bob", Host: "10.0.0.2", Operation: "Write", ResourceType: "Topic", ResourceName: "payments"},
+			allowed: true,
+			reason:  authorizationAllowed,
+			acls:    []string{"User:Bob|*|All|Allow|Topic|payments|Literal"},
+		},
+		{
+			name:    "host specific allow",
+			req:     AuthorizationRequest{Principal: "User:Carol", Host: "*", Operation: "Read", ResourceType: "Group", ResourceName: "billing"},
+			allowed: false,
+			reason:  authorizationNoAllow,
+		},
+		{
+			name:    "wildcard prefixed deny",
+			req:     AuthorizationRequest{Principal: "User:Erin", Host: "*", Operation: "Read", ResourceType: "Topic", ResourceName: "secret-sauce"},
+			allowed: false,
+			reason:  authorizationDenied,
+			acls:    []string{"User:*|*|All|Deny|Topic|secret-|Prefixed"},
+		},
+		{
+			name:    "no acls for resource",
+			req:     AuthorizationRequest{Principal: "User:Alice", Host: "*", Operation: "Read", ResourceType: "Group", ResourceName: "other"},
+			allowed: false,
+			reason:  authorizationNoACLs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := authorize(acls, tt.req, false)
+			if result.Allowed != tt.allowed || result.Reason != tt.reason {
+				t.Errorf("expected allowed=%v reason=%s, got allowed=%v reason=%s", tt.allowed, tt.reason, result.Allowed, result.Reason)
+			}
+			if len(result.DecidingACLs) != len(tt.acls) {
+				t.Fatalf("expected deciding ACLs %v, got %v", tt.acls, result.DecidingACLs)
+			}
+			for i, a := range result.DecidingACLs {
+				if a.String() != tt.acls[i] {
+					t.Errorf("expected deciding ACL %d to be %s, got %s", i, tt.acls[i], a)
+				}
+			}
+		})
+	}
+
+	result := authorize(acls, AuthorizationRequest{Principal: "User:Alice", Host: "*", Operation: "Read", ResourceType: "Group", ResourceName: "other"}, true)
+	if !result.Allowed || result.Reason != authorizationNoACLs {
+		t.Errorf("expected allow.everyone.if.no.acl.found to allow resources without ACLs, got %+v", result)
+	}
+}
@@ -0,0 +1,110 @@
+package kafka
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// defaultACLHostMaxAddresses allows up to a /24 IPv4 range
+const defaultACLHostMaxAddresses = 256
+
+// isACLHostRange reports whether an acl_host is CIDR notation rather than a
+// single address or *
+func isACLHostRange(host string) bool {
+	return strings.Contains(host, "/")
+}
+
+// parseACLHostRange parses a CIDR acl_host, rejecting prefixes with host bits
+// set, since 10.0.0.5/24 is more likely a typo than a request for 10.0.0.0/24
+func parseACLHostRange(host string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(host)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%q is not a valid CIDR range: %w", host, err)
+	}
+	if masked := prefix.Masked(); masked != prefix {
+		return netip.Prefix{}, fmt.Errorf("%q has host bits set, did you mean %s?", host, masked)
+	}
+	return prefix, nil
+}
+
+// aclHostRangeSize returns the number of addresses in the prefix, capped at
+// limit+1 so huge IPv6 ranges do not overflow
+func aclHostRangeSize(prefix netip.Prefix, limit int) int {
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostBits >= 31 || 1<<hostBits > limit {
+		return limit + 1
+	}
+	return 1 << hostBits
+}
+
+// validateACLHostRange checks a CIDR acl_host against the address cap
+func validateACLHostRange(host string, maxAddresses int) error {
+	if !isACLHostRange(host) {
+		return nil
+	}
+	prefix, err := parseACLHostRange(host)
+	if err != nil {
+		return err
+	}
+	if aclHostRangeSize(prefix, maxAddresses) > maxAddresses {
+		return aclHostRangeTooLargeError(host, maxAddresses)
+	}
+	return nil
+}
+
+func aclHostRangeTooLargeError(host string, maxAddresses int) error {
+	return fmt.Errorf("%s contains more than acl_host_max_addresses (%d) addresses", host, maxAddresses)
+}
+
+// aclHostMaxAddresses returns the configured acl_host_max_addresses, or the
+// default when it is unset, such as for imported or older ACLs
+func aclHostMaxAddresses(v interface{}) int {
+	if n, ok := v.(int); ok && n > 0 {
+		return n
+	}
+	return defaultACLHostMaxAddresses
+}
+
+// expandACLHosts returns the broker-side ACLs of an ACL, one per address when
+// acl_host is a CIDR range. Ranges with more than maxAddresses addresses are
+// rejected before anything is allocated, since acl_host may only be known
+// after plan-time validation has run.
+func expandACLHosts(a StringlyTypedACL, maxAddresses int) ([]StringlyTypedACL, error) {
+	if !isACLHostRange(a.ACL.Host) {
+		return []StringlyTypedACL{a}, nil
+	}
+	prefix, err := parseACLHostRange(a.ACL.Host)
+	if err != nil {
+		return nil, err
+	}
+	size := aclHostRangeSize(prefix, maxAddresses)
+	if size > maxAddresses {
+		return nil, aclHostRangeTooLargeError(a.ACL.Host, maxAddresses)
+	}
+
+	acls := make([]StringlyTypedACL, 0, size)
+	for addr := prefix.Addr(); addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		expanded := a
+		expanded.ACL.Host = javaHostAddress(addr)
+		acls = append(acls, expanded)
+	}
+	return acls, nil
+}
+
+// javaHostAddress formats an address the way Kafka's authorizer sees the
+// client host, InetAddress.getHostAddress(): IPv6 addresses are written out
+// in full without zero compression, e.g. fd00:0:0:0:0:0:0:1, and IPv4-mapped
+// addresses as plain IPv4
+func javaHostAddress(addr netip.Addr) string {
+	if addr.Is4() || addr.Is4In6() {
+		return addr.Unmap().String()
+	}
+	b := addr.As16()
+	groups := make([]string, 8)
+	for i := range groups {
+		groups[i] = strconv.FormatUint(uint64(b[2*i])<<8|uint64(b[2*i+1]), 16)
+	}
+	return strings.Join(groups, ":")
+}
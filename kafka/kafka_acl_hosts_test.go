@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpandACLHosts(t *testing.T) {
+	acl := func(host string) StringlyTypedACL {
+		return StringlyTypedACL{
+			ACL:      ACL{Principal: "User:Alice", Host: host, Operation: "Write", PermissionType: "Allow"},
+			Resource: Resource{Type: "Topic", Name: "orders", PatternTypeFilter: "Literal"},
+		}
+	}
+
+	tests := []struct {
+		host     string
+		expected []string
+		err      bool
+	}{
+		{host: "*", expected: []string{"*"}},
+		{host: "10.0.0.1", expected: []string{"10.0.0.1"}},
+		{host: "10.0.0.8/30", expected: []string{"10.0.0.8", "10.0.0.9", "10.0.0.10", "10.0.0.11"}},
+		{host: "10.0.0.1/32", expected: []string{"10.0.0.1"}},
+		{host: "2001:db8::/127", expected: []string{"2001:db8:0:0:0:0:0:0", "2001:db8:0:0:0:0:0:1"}},
+		{host: "fd00::ff/128", expected: []string{"fd00:0:0:0:0:0:0:ff"}},
+		{host: "::ffff:10.0.0.0/127", expected: []string{"10.0.0.0", "10.0.0.1"}},
+		{host: "10.0.0.9/30", err: true},
+		{host: "10.0.0.0/33", err: true},
+		{host: "10.0.0.0/23", err: true},
+		{host: "10.0.0.0/8", err: true},
+		{host: "::/0", err: true},
+	}
+
+	for _, tt := range tests {
+		acls, err := expandACLHosts(acl(tt.host), defaultACLHostMaxAddresses)
+		if tt.err {
+			if err == nil {
+				t.Errorf("expected %q to fail, got %v", tt.host, acls)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", tt.host, err)
+			continue
+		}
+
+		hosts := []string{}
+		for _, a := range acls {
+			hosts = append(hosts, a.ACL.Host)
+			if a.ACL.Principal != "User:Alice" || a.Resource.Name != "orders" {
+				t.Errorf("expected %q to keep the rest of the ACL, got %v", tt.host, a)
+			}
+		}
+		if !slices.Equal(hosts, tt.expected) {
+			t.Errorf("expected %q to expand to %v, got %v", tt.host, tt.expected, hosts)
+		}
+	}
+}
+
+func TestValidateACLHostRange(t *testing.T) {
+	tests := []struct {
+		host         string
+		maxAddresses int
+		err          bool
+	}{
+		{host: "*", maxAddresses: 1},
+		{host: "10.0.0.1", maxAddresses: 1},
+		{host: "10.0.0.0/24", maxAddresses: 256},
+		{host: "10.0.0.0/23", maxAddresses: 256, err: true},
+		{host: "10.0.0.0/8", maxAddresses: 256, err: true},
+		{host: "2001:db8::/64", maxAddresses: 256, err: true},
+		{host: "::/0", maxAddresses: 256, err: true},
+		{host: "10.0.0.1/24", maxAddresses: 256, err: true},
+		{host: "not-a-range/24", maxAddresses: 256, err: true},
+	}
+
+	for _, tt := range tests {
+		err := validateACLHostRange(tt.host, tt.maxAddresses)
+		if tt.err && err == nil {
+			t.Errorf("expected %q with a cap of %d to fail", tt.host, tt.maxAddresses)
+		}
+		if !tt.err && err != nil {
+			t.Errorf("unexpected error for %q: %v", tt.host, err)
+		}
+	}
+}
+
+func TestACLsDifference(t *testing.T) {
+	old, err := expandACLHosts(StringlyTypedACL{ACL: ACL{Principal: "User:Alice", Host: "10.0.0.0/30"}}, defaultACLHostMaxAddresses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := expandACLHosts(StringlyTypedACL{ACL: ACL{Principal: "User:Alice", Host: "10.0.0.2/31"}}, defaultACLHostMaxAddresses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created := aclsDifference(updated, old); len(created) != 0 {
+		t.Errorf("expected no ACLs to create, got %v", created)
+	}
+	deleted := aclsDifference(old, updated)
+	if len(deleted) != 2 || deleted[0].ACL.Host != "10.0.0.0" || deleted[1].ACL.Host != "10.0.0.1" {
+		t.Errorf("expected 10.0.0.0 and 10.0.0.1 to be deleted, got %v", deleted)
+	}
+}
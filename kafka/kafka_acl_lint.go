@@ -0,0 +1,184 @@
+package kafka
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	aclFindingCovered            = "covered"
+	aclFindingRedundantOperation = "redundant_operation"
+	aclFindingShadowed           = "shadowed"
+	aclFindingOverlappingPrefix  = "overlapping_prefix"
+)
+
+var aclFindingKinds = []string{aclFindingCovered, aclFindingRedundantOperation, aclFindingShadowed, aclFindingOverlappingPrefix}
+
+// ACLFinding is a problem with an ACL, caused by the related ACL
+type ACLFinding struct {
+	Kind    string
+	ACL     StringlyTypedACL
+	Related StringlyTypedACL
+	Message string
+}
+
+// lintACLs finds ACLs that have no effect or that grant more than they appear
+// to:
+//   - covered: a literal ACL that a prefixed or wildcard ACL already grants or denies
+//   - redundant_operation: an ACL that an All ACL for the same resource already grants or denies
+//   - shadowed: an Allow ACL that a Deny ACL always overrides
+//   - overlapping_prefix: a prefixed ACL on, or nested inside, the prefix of another principal
+//
+// Each ACL is only compared with the ACLs of the same type that could cover
+// it: the literal ACL of the same name, the literal * ACL and the prefixed
+// ACLs whose prefix it starts with, so clusters with thousands of ACLs are
+// linted in close to linear time.
+func lintACLs(acls []StringlyTypedACL) []ACLFinding {
+	keys := make([]string, len(acls))
+	literal := map[Resource][]int{}
+	prefixed := map[Resource][]int{}
+	for i, a := range acls {
+		keys[i] = a.String()
+		switch a.PatternTypeFilter {
+		case "Literal":
+			literal[a.Resource] = append(literal[a.Resource], i)
+		case "Prefixed":
+			prefixed[a.Resource] = append(prefixed[a.Resource], i)
+		}
+	}
+
+	type keyedFinding struct {
+		ACLFinding
+		key, related string
+	}
+	found := []keyedFinding{}
+	for i, a := range acls {
+		for _, j := range aclLintCandidates(a.Resource, literal, prefixed) {
+			if keys[i] == keys[j] {
+				continue
+			}
+			for _, f := range lintACLPair(a, acls[j]) {
+				found = append(found, keyedFinding{ACLFinding: f, key: keys[i], related: keys[j]})
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Kind != found[j].Kind {
+			return found[i].Kind < found[j].Kind
+		}
+		if found[i].key != found[j].key {
+			return found[i].key < found[j].key
+		}
+		return found[i].related < found[j].related
+	})
+
+	findings := make([]ACLFinding, 0, len(found))
+	for _, f := range found {
+		findings = append(findings, f.ACLFinding)
+	}
+	return findings
+}
+
+// aclLintCandidates returns the indexes of the ACLs that could cover an ACL on
+// r: the literal ACLs on the same name or on *, and the prefixed ACLs whose
+// prefix r's name starts with
+func aclLintCandidates(r Resource, literal map[Resource][]int, prefixed map[Resource][]int) []int {
+	candidates := []int{}
+	candidates = append(candidates, literal[Resource{Type: r.Type, Name: r.Name, PatternTypeFilter: "Literal"}]...)
+	if r.Name != aclWildcardResource {
+		candidates = append(candidates, literal[Resource{Type: r.Type, Name: aclWildcardResource, PatternTypeFilter: "Literal"}]...)
+	}
+	for i := 0; i <= len(r.Name); i++ {
+		candidates = append(candidates, prefixed[Resource{Type: r.Type, Name: r.Name[:i], PatternTypeFilter: "Prefixed"}]...)
+	}
+	return candidates
+}
+
+// lintACLPair reports the findings for acl caused by other
+func lintACLPair(acl, other StringlyTypedACL) []ACLFinding {
+	findings := []ACLFinding{}
+	finding := func(kind, format string, args ...interface{}) {
+		findings = append(findings, ACLFinding{Kind: kind, ACL: acl, Related: other, Message: fmt.Sprintf(format, args...)})
+	}
+
+	appliesToAll := aclPrincipalCovers(other.ACL.Principal, acl.ACL.Principal) &&
+		aclHostCovers(other.ACL.Host, acl.ACL.Host) &&
+		aclResourceCovers(other.Resource, acl.Resource)
+
+	if appliesToAll && other.ACL.PermissionType == acl.ACL.PermissionType && aclOperationCovers(other.ACL.Operation, acl.ACL.Operation) {
+		if acl.PatternTypeFilter == "Literal" && acl.Name != aclWildcardResource && !sameResource(acl.Resource, other.Resource) {
+			finding(aclFindingCovered, "%s on %s is already covered by the %s ACL on %s",
+				acl.ACL.Operation, acl.Name, strings.ToLower(aclPatternDescription(other.Resource)), other.Name)
+		}
+		if other.ACL.Operation == "All" && acl.ACL.Operation != "All" && sameResource(acl.Resource, other.Resource) {
+			finding(aclFindingRedundantOperation, "%s is redundant, All is already %s on %s",
+				acl.ACL.Operation, aclPermissionPastTense(acl.ACL.PermissionType), acl.Name)
+		}
+	}
+
+	if appliesToAll && acl.ACL.PermissionType == "Allow" && other.ACL.PermissionType == "Deny" && aclOperationCovers(other.ACL.Operation, acl.ACL.Operation) {
+		finding(aclFindingShadowed, "Allow %s on %s never takes effect, it is overridden by Deny %s on %s",
+			acl.ACL.Operation, acl.Name, other.ACL.Operation, other.Name)
+	}
+
+	if acl.PatternTypeFilter == "Prefixed" && other.PatternTypeFilter == "Prefixed" &&
+		strings.HasPrefix(acl.Name, other.Name) &&
+		acl.ACL.Principal != other.ACL.Principal &&
+		acl.ACL.Principal != aclWildcardPrincipal && other.ACL.Principal != aclWildcardPrincipal {
+		finding(aclFindingOverlappingPrefix, "the prefix %s of %s overlaps with the prefix %s of %s",
+			acl.Name, acl.ACL.Principal, other.Name, other.ACL.Principal)
+	}
+
+	return findings
+}
+
+func aclPrincipalCovers(covering, covered string) bool {
+	return covering == covered || covering == aclWildcardPrincipal
+}
+
+func aclHostCovers(covering, covered string) bool {
+	return covering == covered || covering == aclWildcardHost
+}
+
+func aclOperationCovers(covering, covered string) bool {
+	return covering == covered || covering == "All"
+}
+
+// aclResourceCovers reports whether every resource matched by covered is also
+// matched by covering
+func aclResourceCovers(covering, covered Resource) bool {
+	if covering.Type != covered.Type {
+		return false
+	}
+
+	switch covering.PatternTypeFilter {
+	case "Literal":
+		if covering.Name == aclWildcardResource {
+			return true
+		}
+		return covered.PatternTypeFilter == "Literal" && covered.Name == covering.Name
+	case "Prefixed":
+		return covered.Name != aclWildcardResource && strings.HasPrefix(covered.Name, covering.Name)
+	}
+	return false
+}
+
+func sameResource(a, b Resource) bool {
+	return a.Type == b.Type && a.Name == b.Name && a.PatternTypeFilter == b.PatternTypeFilter
+}
+
+func aclPatternDescription(r Resource) string {
+	if r.PatternTypeFilter == "Literal" && r.Name == aclWildcardResource {
+		return "Wildcard"
+	}
+	return r.PatternTypeFilter
+}
+
+func aclPermissionPastTense(permissionType string) string {
+	if permissionType == "Deny" {
+		return "denied"
+	}
+	return "allowed"
+}
@@ -0,0 +1,159 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLintACLs(t *testing.T) {
+	acl := func(principal, host, op, permission, resourceType, name, pattern string) StringlyTypedACL {
+		return StringlyTypedACL{
+			ACL:      ACL{Principal: principal, Host: host, Operation: op, PermissionType: permission},
+			Resource: Resource{Type: resourceType, Name: name, PatternTypeFilter: pattern},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		acls     []StringlyTypedACL
+		expected []string
+	}{
+		{
+			name: "literal covered by prefix",
+			acls: []StringlyTypedACL{
+				acl("User:Alice", "*", "Read", "Allow", "Topic", "orders-eu", "Literal"),
+				acl("User:Alice", "*", "Read", "Allow", "Topic", "orders-", "Prefixed"),
+			},
+			expected: []string{"covered User:Alice|*|Read|Allow|Topic|orders-eu|Literal by User:Alice|*|Read|Allow|Topic|orders-|Prefixed"},
+		},
+		{
+			name: "literal covered by wildcard principal and resource",
+			acls: []StringlyTypedACL{
+				acl("User:Alice", "10.0.0.1", "Describe", "Allow", "Topic", "orders", "Literal"),
+				acl("User:*", "*", "Describe", "Allow", "Topic", "*", "Literal"),
+			},
+			expected: []string{"covered User:Alice|10.0.0.1|Describe|Allow|Topic|orders|Literal by User:*|*|Describe|Allow|Topic|*|Literal"},
+		},
+		{
+			name: "narrower host is not covered",
+			acls: []StringlyTypedACL{
+				acl("User:Alice", "*", "Read", "Allow", "Topic", "orders-eu", "Literal"),
+				acl("User:Alice", "10.0.0.1", "Read", "Allow", "Topic", "orders-", "Prefixed"),
+			},
+			expected: []string{},
+		},
+		{
+			name: "operation redundant with All",
+			acls: []StringlyTypedACL{
+				acl("User:Alice", "*", "Read", "Allow", "Group", "billing", "Literal"),
+				acl("User:Alice", "*", "All", "Allow", "Group", "billing", "Literal"),
+			},
+			expected: []string{"redundant_operation User:Alice|*|Read|Allow|Group|billing|Literal by User:Alice|*|All|Allow|Group|billing|Literal"},
+		},
+		{
+			name: "allow shadowed by deny",
+			acls: []StringlyTypedACL{
+				acl("User:Alice", "*", "Write", "Allow", "Topic", "secret-data", "Literal"),
+				acl("User:*", "*", "All", "Deny", "Topic", "secret-", "Prefixed"),
+			},
+			expected: []string{"shadowed User:Alice|*|Write|Allow|Topic|secret-data|Literal by User:*|*|All|Deny|Topic|secret-|Prefixed"},
+		},
+		{
+			name: "narrower deny does not shadow",
+			acls: []StringlyTypedACL{
+				acl("User:Alice", "*", "Write", "Allow", "Topic", "secret-", "Prefixed"),
+				acl("User:Alice", "*", "Write", "Deny", "Topic", "secret-data", "Literal"),
+			},
+			expected: []string{},
+		},
+		{
+			name: "overlapping prefixes between principals",
+			acls: []StringlyTypedACL{
+				acl("User:Alice", "*", "Read", "Allow", "Topic", "orders-", "Prefixed"),
+				acl("User:Bob", "*", "Read", "Allow", "Topic", "orders-eu-", "Prefixed"),
+			},
+			expected: []string{"overlapping_prefix User:Bob|*|Read|Allow|Topic|orders-eu-|Prefixed by User:Alice|*|Read|Allow|Topic|orders-|Prefixed"},
+		},
+		{
+			name: "identical prefixes between principals",
+			acls: []StringlyTypedACL{
+				acl("User:Alice", "*", "Read", "Allow", "Topic", "orders-", "Prefixed"),
+				acl("User:Bob", "*", "Write", "Allow", "Topic", "orders-", "Prefixed"),
+				acl("User:Bob", "*", "Describe", "Allow", "Topic", "orders-", "Prefixed"),
+			},
+			expected: []string{
+				"overlapping_prefix User:Alice|*|Read|Allow|Topic|orders-|Prefixed by User:Bob|*|Describe|Allow|Topic|orders-|Prefixed",
+				"overlapping_prefix User:Alice|*|Read|Allow|Topic|orders-|Prefixed by User:Bob|*|Write|Allow|Topic|orders-|Prefixed",
+				"overlapping_prefix User:Bob|*|Describe|Allow|Topic|orders-|Prefixed by User:Alice|*|Read|Allow|Topic|orders-|Prefixed",
+				"overlapping_prefix User:Bob|*|Write|Allow|Topic|orders-|Prefixed by User:Alice|*|Read|Allow|Topic|orders-|Prefixed",
+			},
+		},
+		{
+			name: "different resource types do not interact",
+			acls: []StringlyTypedACL{
+				acl("User:Alice", "*", "Read", "Allow", "Topic", "orders", "Literal"),
+				acl("User:Alice", "*", "All", "Deny", "Group", "*", "Literal"),
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := lintACLs(tt.acls)
+			if len(findings) != len(tt.expected) {
+				t.Fatalf("expected %d findings, got %v", len(tt.expected), findings)
+			}
+			for i, f := range findings {
+				got := f.Kind + " " + f.ACL.String() + " by " + f.Related.String()
+				if got != tt.expected[i] {
+					t.Errorf("expected finding %q, got %q", tt.expected[i], got)
+				}
+				if f.Message == "" {
+					t.Errorf("expected finding %q to have a message", got)
+				}
+			}
+		})
+	}
+}
+
+func TestACLResourceCovers(t *testing.T) {
+	tests := []struct {
+		covering Resource
+		covered  Resource
+		expected bool
+	}{
+		{Resource{"Topic", "*", "Literal"}, Resource{"Topic", "orders", "Literal"}, true},
+		{Resource{"Topic", "*", "Literal"}, Resource{"Topic", "orders-", "Prefixed"}, true},
+		{Resource{"Topic", "orders-", "Prefixed"}, Resource{"Topic", "orders-eu", "Literal"}, true},
+		{Resource{"Topic", "orders-", "Prefixed"}, Resource{"Topic", "orders-eu-", "Prefixed"}, true},
+		{Resource{"Topic", "orders-", "Prefixed"}, Resource{"Topic", "*", "Literal"}, false},
+		{Resource{"Topic", "orders-eu", "Literal"}, Resource{"Topic", "orders-eu-", "Prefixed"}, false},
+		{Resource{"Topic", "orders", "Literal"}, Resource{"Group", "orders", "Literal"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := aclResourceCovers(tt.covering, tt.covered); got != tt.expected {
+			t.Errorf("expected %v to cover %v to be %v, got %v", tt.covering, tt.covered, tt.expected, got)
+		}
+	}
+}
+
+func BenchmarkLintACLs(b *testing.B) {
+	acls := make([]StringlyTypedACL, 0, 5000)
+	for i := 0; i < cap(acls); i++ {
+		pattern := "Literal"
+		if i%10 == 0 {
+			pattern = "Prefixed"
+		}
+		acls = append(acls, StringlyTypedACL{
+			ACL:      ACL{Principal: fmt.Sprintf("User:service-%d", i%100), Host: "*", Operation: "Read", PermissionType: "Allow"},
+			Resource: Resource{Type: "Topic", Name: fmt.Sprintf("team-%d-orders-%d", i%20, i), PatternTypeFilter: pattern},
+		})
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lintACLs(acls)
+	}
+}
@@ -1,14 +1,120 @@
 package kafka
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/IBM/sarama"
 )
 
+// The values an ACL binding can be created with
+var (
+	aclResourceTypes   = []string{"Topic", "Group", "Cluster", "TransactionalID", "DelegationToken"}
+	aclOperations      = []string{"All", "Read", "Write", "Create", "Delete", "Alter", "Describe", "ClusterAction", "DescribeConfigs", "AlterConfigs", "IdempotentWrite"}
+	aclPermissionTypes = []string{"Allow", "Deny"}
+)
+
+// aclResourceOperations are the operations Kafka authorizes on each resource
+// type, as documented in "Operations and Resources on Protocols"
+var aclResourceOperations = map[string][]string{
+	"Topic":           {"All", "Read", "Write", "Create", "Delete", "Alter", "Describe", "DescribeConfigs", "AlterConfigs"},
+	"Group":           {"All", "Read", "Delete", "Describe"},
+	"Cluster":         {"All", "Create", "Alter", "Describe", "ClusterAction", "DescribeConfigs", "AlterConfigs", "IdempotentWrite"},
+	"TransactionalID": {"All", "Write", "Describe"},
+	"DelegationToken": {"All", "Describe"},
+}
+
+// aclOperationAlternatives lists, for each operation, the operations closest
+// to it in order of preference
+var aclOperationAlternatives = map[string][]string{
+	"Read":            {"Describe"},
+	"Write":           {"IdempotentWrite", "Describe"},
+	"Create":          {"Describe"},
+	"Delete":          {"Describe"},
+	"Alter":           {"AlterConfigs", "Describe"},
+	"ClusterAction":   {"Alter", "Describe"},
+	"DescribeConfigs": {"Describe"},
+	"AlterConfigs":    {"Alter", "DescribeConfigs", "Describe"},
+	"IdempotentWrite": {"Write", "Describe"},
+}
+
+// aclOperationWarning explains why an operation is never authorized on a
+// resource type, suggesting the nearest operation that is. It returns an empty
+// string for legal or unrecognised combinations.
+func aclOperationWarning(resourceType, operation string) string {
+	ops, ok := aclResourceOperations[resourceType]
+	if !ok || !slices.Contains(aclOperations, operation) || slices.Contains(ops, operation) {
+		return ""
+	}
+
+	suggestion := "All"
+	for _, alt := range aclOperationAlternatives[operation] {
+		if slices.Contains(ops, alt) {
+			suggestion = alt
+			break
+		}
+	}
+
+	return fmt.Sprintf("%s is not a valid operation on %s resources and will never be authorized, did you mean %s? Valid operations are: %s",
+		operation, resourceType, suggestion, strings.Join(ops, ", "))
+}
+
+// validateACLEnum validates that a value is one of valid, suggesting the
+// closest value for typos
+func validateACLEnum(valid []string) func(interface{}, string) ([]string, []error) {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+		if slices.Contains(valid, v) {
+			return nil, nil
+		}
+
+		msg := fmt.Sprintf("expected %s to be one of %s, got %s", k, strings.Join(valid, ", "), v)
+		if closest := closestString(v, valid); closest != "" {
+			msg += fmt.Sprintf(", did you mean %s?", closest)
+		}
+		return nil, []error{errors.New(msg)}
+	}
+}
+
+// closestString returns the candidate within an edit distance of 2 of s,
+// ignoring case, or an empty string if there is none
+func closestString(s string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, c := range candidates {
+		if d := levenshtein(strings.ToLower(s), strings.ToLower(c)); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
 type ACL struct {
 	Principal      string `json:"principal"`
 	Host           string `json:"host"`
@@ -102,6 +208,84 @@ func tfToAclFilter(s StringlyTypedACL) (sarama.AclFilter, error) {
 	return f, nil
 }
 
+// tfToAnyAclFilter is like tfToAclFilter, but empty fields match any value
+func tfToAnyAclFilter(s StringlyTypedACL) (sarama.AclFilter, error) {
+	f := sarama.AclFilter{
+		Operation:                 sarama.AclOperationAny,
+		PermissionType:            sarama.AclPermissionAny,
+		ResourceType:              sarama.AclResourceAny,
+		ResourcePatternTypeFilter: sarama.AclPatternAny,
+	}
+
+	if s.ACL.Principal != "" {
+		f.Principal = &s.ACL.Principal
+	}
+	if s.ACL.Host != "" {
+		f.Host = &s.ACL.Host
+	}
+	if s.Name != "" {
+		f.ResourceName = &s.Name
+	}
+
+	if s.ACL.Operation != "" {
+		op := stringToOperation(s.ACL.Operation)
+		if op == unknownConversion {
+			return f, fmt.Errorf("unknown operation: %s", s.ACL.Operation)
+		}
+		f.Operation = op
+	}
+
+	if s.ACL.PermissionType != "" {
+		pType := stringToAclPermissionType(s.ACL.PermissionType)
+		if pType == unknownConversion {
+			return f, fmt.Errorf("unknown permission type: %s", s.ACL.PermissionType)
+		}
+		f.PermissionType = pType
+	}
+
+	if s.Type != "" {
+		rType := stringToACLResource(s.Type)
+		if rType == unknownConversion {
+			return f, fmt.Errorf("unknown resource type: %s", s.Type)
+		}
+		f.ResourceType = rType
+	}
+
+	if s.PatternTypeFilter != "" {
+		patternType := stringToACLPrefix(s.PatternTypeFilter)
+		if patternType == unknownConversion {
+			return f, fmt.Errorf("unknown pattern type filter: '%s'", s.PatternTypeFilter)
+		}
+		f.ResourcePatternTypeFilter = patternType
+	}
+
+	return f, nil
+}
+
+// flattenResourceACLs converts the ResourceAcls returned by Kafka into one
+// StringlyTypedACL per binding
+func flattenResourceACLs(resourceACLs []*sarama.ResourceAcls) []StringlyTypedACL {
+	res := []StringlyTypedACL{}
+	for _, foundACLs := range resourceACLs {
+		for _, acl := range foundACLs.Acls {
+			res = append(res, StringlyTypedACL{
+				ACL: ACL{
+					Principal:      acl.Principal,
+					Host:           acl.Host,
+					Operation:      ACLOperationToString(acl.Operation),
+					PermissionType: ACLPermissionTypeToString(acl.PermissionType),
+				},
+				Resource: Resource{
+					Type:              ACLResourceToString(foundACLs.ResourceType),
+					Name:              foundACLs.ResourceName,
+					PatternTypeFilter: foundACLs.ResourcePatternType.String(),
+				},
+			})
+		}
+	}
+	return res
+}
+
 func stringToACLPrefix(s string) sarama.AclResourcePatternType {
 	switch s {
 	case "Any":
@@ -143,6 +327,7 @@ func (c *Client) enqueueDeleteACL(broker *sarama.Broker, filter *sarama.AclFilte
 
 		res, err := broker.DeleteAcls(req)
 		if err != nil {
+			c.InvalidateACLCache()
 			for _, wc := range c.aclDeletionQueue.waitChans {
 				wc <- err
 			}
@@ -150,13 +335,14 @@ func (c *Client) enqueueDeleteACL(broker *sarama.Broker, filter *sarama.AclFilte
 		}
 
 		if len(res.FilterResponses) != len(c.aclDeletionQueue.waitChans) {
+			c.InvalidateACLCache()
 			for _, ch := range c.aclDeletionQueue.waitChans {
 				ch <- fmt.Errorf("unexpectedly got a different length (%d) of FilterResponses compared to queued requests (%d) - this shouldn't be possible", len(res.FilterResponses), len(c.aclDeletionQueue.waitChans))
 			}
 			return
 		}
 
-		c.InvalidateACLCache()
+		c.aclCache.cacheDeleted(res.FilterResponses)
 		for i, r := range res.FilterResponses {
 			if r.Err != sarama.ErrNoError {
 				c.aclDeletionQueue.waitChans[i] <- r.Err
@@ -217,19 +403,21 @@ func (c *Client) enqueueCreateACL(broker *sarama.Broker, create *sarama.AclCreat
 
 		res, err := broker.CreateAcls(req)
 		if err != nil {
+			c.InvalidateACLCache()
 			for _, wc := range c.aclCreationQueue.waitChans {
 				wc <- err
 			}
 			return
 		}
 		if len(res.AclCreationResponses) != len(c.aclCreationQueue.waitChans) {
+			c.InvalidateACLCache()
 			for _, ch := range c.aclCreationQueue.waitChans {
 				ch <- fmt.Errorf("unexpectedly got a different length (%d) of AclCreationResponses compared to queued requests (%d) - this shouldn't be possible", len(res.AclCreationResponses), len(c.aclCreationQueue.waitChans))
 			}
 			return
 		}
 
-		c.InvalidateACLCache()
+		c.aclCache.cacheCreated(createdACLs(c.aclCreationQueue.creations, res.AclCreationResponses))
 
 		for i, r := range res.AclCreationResponses {
 			if r.Err != sarama.ErrNoError {
@@ -266,6 +454,92 @@ func (c *Client) CreateACL(s StringlyTypedACL) error {
 	return nil
 }
 
+// CreateACLs creates all of the ACLs in a single CreateAcls request
+func (c *Client) CreateACLs(acls []StringlyTypedACL) error {
+	if len(acls) == 0 {
+		return nil
+	}
+
+	broker, err := c.client.Controller()
+	if err != nil {
+		return err
+	}
+
+	creations := make([]*sarama.AclCreation, 0, len(acls))
+	for _, s := range acls {
+		ac, err := tfToAclCreation(s)
+		if err != nil {
+			return err
+		}
+		creations = append(creations, ac)
+	}
+
+	req := &sarama.CreateAclsRequest{
+		Version:      c.getCreateAclsRequestAPIVersion(),
+		AclCreations: creations,
+	}
+
+	log.Printf("[INFO] Creating %d ACLs", len(creations))
+	res, err := broker.CreateAcls(req)
+	if err != nil {
+		c.InvalidateACLCache()
+		return err
+	}
+	c.aclCache.cacheCreated(createdACLs(creations, res.AclCreationResponses))
+
+	errs := []error{}
+	for i, r := range res.AclCreationResponses {
+		if r.Err != sarama.ErrNoError && i < len(acls) {
+			errs = append(errs, fmt.Errorf("%s: %w", acls[i], r.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DeleteACLs deletes all of the ACLs in a single DeleteAcls request
+func (c *Client) DeleteACLs(acls []StringlyTypedACL) error {
+	if len(acls) == 0 {
+		return nil
+	}
+
+	broker, err := c.client.Controller()
+	if err != nil {
+		return err
+	}
+
+	filters := make([]*sarama.AclFilter, 0, len(acls))
+	for _, s := range acls {
+		filter, err := tfToAclFilter(s)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, &filter)
+	}
+
+	req := &sarama.DeleteAclsRequest{
+		Version: int(c.getDeleteAclsRequestAPIVersion()),
+		Filters: filters,
+	}
+
+	log.Printf("[INFO] Deleting %d ACLs", len(filters))
+	res, err := broker.DeleteAcls(req)
+	if err != nil {
+		c.InvalidateACLCache()
+		return err
+	}
+	c.aclCache.cacheDeleted(res.FilterResponses)
+
+	errs := []error{}
+	for i, r := range res.FilterResponses {
+		if r.Err != sarama.ErrNoError && i < len(acls) {
+			errs = append(errs, fmt.Errorf("%s: %w", acls[i], r.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func stringToACLResource(in string) sarama.AclResourceType {
 	switch in {
 	case "Unknown":
@@ -432,11 +706,46 @@ func (c *Client) DescribeACLs(s StringlyTypedACL) ([]*sarama.ResourceAcls, error
 	return aclsR.ResourceAcls, nil
 }
 
+// FilterACLs describes the ACLs matching s, where empty fields match any value
+// and the Match pattern type finds every literal, prefixed and wildcard ACL that
+// applies to the resource name
+func (c *Client) FilterACLs(s StringlyTypedACL) ([]StringlyTypedACL, error) {
+	aclFilter, err := tfToAnyAclFilter(s)
+	if err != nil {
+		return nil, err
+	}
+
+	broker, err := c.client.Controller()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &sarama.DescribeAclsRequest{
+		Version:   int(c.getDescribeAclsRequestAPIVersion()),
+		AclFilter: aclFilter,
+	}
+
+	log.Printf("[TRACE] Describe Acl Request %v", r)
+	aclsR, err := broker.DescribeAcls(r)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[TRACE] ThrottleTime: %d", aclsR.ThrottleTime)
+
+	if aclsR.Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("%s", aclsR.Err)
+	}
+
+	return flattenResourceACLs(aclsR.ResourceAcls), nil
+}
+
 //nolint:staticcheck // QF1008: keeping explicit embedded field names for code clarity
 func (c *Client) InvalidateACLCache() {
 	c.aclCache.mutex.Lock()
 	c.aclCache.valid = false
 	c.aclCache.acls = nil
+	c.aclCache.byName = nil
 	c.aclCache.mutex.Unlock()
 }
 
@@ -466,72 +775,145 @@ func (c *Client) ListACLs() ([]*sarama.ResourceAcls, error) {
 		return nil, err
 	}
 
-	allResources := []*sarama.DescribeAclsRequest{
-		&sarama.DescribeAclsRequest{
-			Version: int(c.getDescribeAclsRequestAPIVersion()),
-			AclFilter: sarama.AclFilter{
-				ResourceType:              sarama.AclResourceTopic,
-				ResourcePatternTypeFilter: sarama.AclPatternAny,
-				PermissionType:            sarama.AclPermissionAny,
-				Operation:                 sarama.AclOperationAny,
-			},
-		},
-		&sarama.DescribeAclsRequest{
-			Version: int(c.getDescribeAclsRequestAPIVersion()),
-			AclFilter: sarama.AclFilter{
-				ResourceType:              sarama.AclResourceGroup,
-				ResourcePatternTypeFilter: sarama.AclPatternAny,
-				PermissionType:            sarama.AclPermissionAny,
-				Operation:                 sarama.AclOperationAny,
-			},
-		},
-		&sarama.DescribeAclsRequest{
-			Version: int(c.getDescribeAclsRequestAPIVersion()),
-			AclFilter: sarama.AclFilter{
-				ResourceType:              sarama.AclResourceCluster,
-				ResourcePatternTypeFilter: sarama.AclPatternAny,
-				PermissionType:            sarama.AclPermissionAny,
-				Operation:                 sarama.AclOperationAny,
-			},
-		},
-		&sarama.DescribeAclsRequest{
-			Version: int(c.getDescribeAclsRequestAPIVersion()),
-			AclFilter: sarama.AclFilter{
-				ResourceType:              sarama.AclResourceTransactionalID,
-				ResourcePatternTypeFilter: sarama.AclPatternAny,
-				PermissionType:            sarama.AclPermissionAny,
-				Operation:                 sarama.AclOperationAny,
-			},
-		},
-		&sarama.DescribeAclsRequest{
-			Version: int(c.getDescribeAclsRequestAPIVersion()),
-			AclFilter: sarama.AclFilter{
-				ResourceType:              sarama.AclResourceDelegationToken,
-				ResourcePatternTypeFilter: sarama.AclPatternAny,
-				PermissionType:            sarama.AclPermissionAny,
-				Operation:                 sarama.AclOperationAny,
-			},
+	r := &sarama.DescribeAclsRequest{
+		Version: int(c.getDescribeAclsRequestAPIVersion()),
+		AclFilter: sarama.AclFilter{
+			ResourceType:              sarama.AclResourceAny,
+			ResourcePatternTypeFilter: sarama.AclPatternAny,
+			PermissionType:            sarama.AclPermissionAny,
+			Operation:                 sarama.AclOperationAny,
 		},
 	}
-	res := []*sarama.ResourceAcls{}
 
-	log.Printf("[TRACE] Asking Kafka for all the resources")
-	for _, r := range allResources {
-		log.Printf("[TRACE] Describe Acl Requst %v", r)
-		aclsR, err := broker.DescribeAcls(r)
-		if err != nil {
-			return nil, err
+	log.Printf("[TRACE] Describe Acl Request %v", r)
+	aclsR, err := broker.DescribeAcls(r)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[TRACE] ThrottleTime: %d", aclsR.ThrottleTime)
+
+	if aclsR.Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("%s", aclsR.Err)
+	}
+
+	c.aclCache.store(aclsR.ResourceAcls)
+	return aclsR.ResourceAcls, nil
+}
+
+// ResourceACLs lists the cached ACLs bound to resources with the given name,
+// filling the cache first if needed
+//
+//nolint:staticcheck // QF1008: keeping explicit embedded field names for code clarity
+func (c *Client) ResourceACLs(name string) ([]*sarama.ResourceAcls, error) {
+	if _, err := c.ListACLs(); err != nil {
+		return nil, err
+	}
+
+	c.aclCache.mutex.RLock()
+	defer c.aclCache.mutex.RUnlock()
+	return c.aclCache.byName[name], nil
+}
+
+// store replaces the cached ACLs. The caller must hold the write lock.
+func (ac *aclCache) store(acls []*sarama.ResourceAcls) {
+	ac.acls = acls
+	ac.byName = indexACLsByName(acls)
+	ac.valid = true
+}
+
+// cacheCreated adds ACLs the broker reported as created to a valid cache
+func (ac *aclCache) cacheCreated(creations []*sarama.AclCreation) {
+	ac.mutex.Lock()
+	defer ac.mutex.Unlock()
+	if !ac.valid {
+		return
+	}
+
+	acls := ac.acls
+	for _, cr := range creations {
+		acls = withACL(acls, cr.Resource, cr.Acl)
+	}
+	ac.store(acls)
+}
+
+// createdACLs lists the creations the broker reported as successful
+func createdACLs(creations []*sarama.AclCreation, responses []*sarama.AclCreationResponse) []*sarama.AclCreation {
+	created := make([]*sarama.AclCreation, 0, len(creations))
+	for i, r := range responses {
+		if i < len(creations) && r.Err == sarama.ErrNoError {
+			created = append(created, creations[i])
+		}
+	}
+	return created
+}
+
+// cacheDeleted removes ACLs the broker reported as deleted from a valid cache
+func (ac *aclCache) cacheDeleted(responses []*sarama.FilterResponse) {
+	ac.mutex.Lock()
+	defer ac.mutex.Unlock()
+	if !ac.valid {
+		return
+	}
+
+	acls := ac.acls
+	for _, r := range responses {
+		if r.Err != sarama.ErrNoError {
+			continue
+		}
+		for _, m := range r.MatchingAcls {
+			if m.Err == sarama.ErrNoError {
+				acls = withoutACL(acls, m.Resource, m.Acl)
+			}
 		}
+	}
+	ac.store(acls)
+}
 
-		log.Printf("[TRACE] ThrottleTime: %d", aclsR.ThrottleTime)
+func indexACLsByName(acls []*sarama.ResourceAcls) map[string][]*sarama.ResourceAcls {
+	byName := make(map[string][]*sarama.ResourceAcls, len(acls))
+	for _, ra := range acls {
+		byName[ra.ResourceName] = append(byName[ra.ResourceName], ra)
+	}
+	return byName
+}
 
-		if aclsR.Err != sarama.ErrNoError {
-			return nil, fmt.Errorf("%s", aclsR.Err)
+// withACL returns acls with acl bound to res. ResourceAcls are copied rather
+// than modified, as callers of ListACLs may still be reading them.
+func withACL(acls []*sarama.ResourceAcls, res sarama.Resource, acl sarama.Acl) []*sarama.ResourceAcls {
+	out := make([]*sarama.ResourceAcls, 0, len(acls)+1)
+	found := false
+	for _, ra := range acls {
+		if ra.Resource != res {
+			out = append(out, ra)
+			continue
+		}
+		found = true
+		if slices.ContainsFunc(ra.Acls, func(a *sarama.Acl) bool { return *a == acl }) {
+			out = append(out, ra)
+			continue
 		}
+		out = append(out, &sarama.ResourceAcls{Resource: res, Acls: append(slices.Clone(ra.Acls), &acl)})
+	}
+	if !found {
+		out = append(out, &sarama.ResourceAcls{Resource: res, Acls: []*sarama.Acl{&acl}})
+	}
+	return out
+}
 
-		res = append(res, aclsR.ResourceAcls...)
+// withoutACL returns acls without acl bound to res, dropping resources that are
+// left without ACLs. Like withACL, it never modifies acls.
+func withoutACL(acls []*sarama.ResourceAcls, res sarama.Resource, acl sarama.Acl) []*sarama.ResourceAcls {
+	out := make([]*sarama.ResourceAcls, 0, len(acls))
+	for _, ra := range acls {
+		if ra.Resource != res {
+			out = append(out, ra)
+			continue
+		}
+		remaining := slices.DeleteFunc(slices.Clone(ra.Acls), func(a *sarama.Acl) bool { return *a == acl })
+		if len(remaining) > 0 {
+			out = append(out, &sarama.ResourceAcls{Resource: res, Acls: remaining})
+		}
 	}
-	c.aclCache.valid = true
-	c.aclCache.acls = res
-	return res, nil
+	return out
 }
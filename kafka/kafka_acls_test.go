@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -230,3 +231,193 @@ func TestListACLs_RaceConditionPrevention(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestTfToAnyAclFilter(t *testing.T) {
+	f, err := tfToAnyAclFilter(StringlyTypedACL{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Principal != nil || f.Host != nil || f.ResourceName != nil {
+		t.Errorf("expected empty fields to match anything, got %v", f)
+	}
+	if f.Operation != sarama.AclOperationAny || f.PermissionType != sarama.AclPermissionAny ||
+		f.ResourceType != sarama.AclResourceAny || f.ResourcePatternTypeFilter != sarama.AclPatternAny {
+		t.Errorf("expected enum fields to default to Any, got %v", f)
+	}
+
+	f, err = tfToAnyAclFilter(StringlyTypedACL{
+		ACL:      ACL{Principal: "User:Alice", Operation: "Read"},
+		Resource: Resource{Type: "Topic", Name: "payments", PatternTypeFilter: "Match"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *f.Principal != "User:Alice" || *f.ResourceName != "payments" || f.Host != nil {
+		t.Errorf("unexpected filter %v", f)
+	}
+	if f.Operation != sarama.AclOperationRead || f.ResourceType != sarama.AclResourceTopic ||
+		f.ResourcePatternTypeFilter != sarama.AclPatternMatch {
+		t.Errorf("unexpected filter %v", f)
+	}
+
+	if _, err := tfToAnyAclFilter(StringlyTypedACL{ACL: ACL{Operation: "Reed"}}); err == nil {
+		t.Error("expected an unknown operation to be rejected")
+	}
+}
+
+func TestFlattenResourceACLs(t *testing.T) {
+	acls := flattenResourceACLs([]*sarama.ResourceAcls{
+		{
+			Resource: sarama.Resource{
+				ResourceType:        sarama.AclResourceTopic,
+				ResourceName:        "payments",
+				ResourcePatternType: sarama.AclPatternPrefixed,
+			},
+			Acls: []*sarama.Acl{
+				{Principal: "User:Alice", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow},
+				{Principal: "User:Bob", Host: "*", Operation: sarama.AclOperationWrite, PermissionType: sarama.AclPermissionDeny},
+			},
+		},
+	})
+
+	if len(acls) != 2 {
+		t.Fatalf("expected 2 ACLs, got %d", len(acls))
+	}
+	if acls[0].String() != "User:Alice|*|Read|Allow|Topic|payments|Prefixed" {
+		t.Errorf("unexpected ACL %s", acls[0])
+	}
+	if acls[1].String() != "User:Bob|*|Write|Deny|Topic|payments|Prefixed" {
+		t.Errorf("unexpected ACL %s", acls[1])
+	}
+}
+
+func TestACLOperationWarning(t *testing.T) {
+	tests := []struct {
+		resourceType string
+		operation    string
+		suggestion   string
+	}{
+		{"Topic", "Read", ""},
+		{"Cluster", "IdempotentWrite", ""},
+		{"Group", "All", ""},
+		{"Topic", "ClusterAction", "did you mean Alter?"},
+		{"Cluster", "Read", "did you mean Describe?"},
+		{"Cluster", "Write", "did you mean IdempotentWrite?"},
+		{"Group", "Write", "did you mean Describe?"},
+		{"TransactionalID", "IdempotentWrite", "did you mean Write?"},
+		{"DelegationToken", "AlterConfigs", "did you mean Describe?"},
+		// Unknown values are reported by the enum validation instead
+		{"Topik", "Read", ""},
+		{"Topic", "Reed", ""},
+	}
+
+	for _, tt := range tests {
+		warning := aclOperationWarning(tt.resourceType, tt.operation)
+		if tt.suggestion == "" {
+			if warning != "" {
+				t.Errorf("expected %s on %s to be valid, got %q", tt.operation, tt.resourceType, warning)
+			}
+			continue
+		}
+		if !strings.Contains(warning, tt.suggestion) {
+			t.Errorf("expected the warning for %s on %s to contain %q, got %q", tt.operation, tt.resourceType, tt.suggestion, warning)
+		}
+	}
+}
+
+func TestValidateACLEnum(t *testing.T) {
+	validate := validateACLEnum(aclOperations)
+
+	if _, errs := validate("Describe", "acl_operation"); len(errs) != 0 {
+		t.Errorf("expected Describe to be valid, got %v", errs)
+	}
+
+	for value, suggestion := range map[string]string{
+		"Reed":           "did you mean Read?",
+		"describe":       "did you mean Describe?",
+		"IdempotentWrit": "did you mean IdempotentWrite?",
+	} {
+		_, errs := validate(value, "acl_operation")
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), suggestion) {
+			t.Errorf("expected %s to be rejected with %q, got %v", value, suggestion, errs)
+		}
+	}
+
+	_, errs := validate("Superuser", "acl_operation")
+	if len(errs) != 1 || strings.Contains(errs[0].Error(), "did you mean") {
+		t.Errorf("expected Superuser to be rejected without a suggestion, got %v", errs)
+	}
+}
+
+func TestACLCache_IncrementalUpdates(t *testing.T) {
+	topic := sarama.Resource{ResourceType: sarama.AclResourceTopic, ResourceName: "payments", ResourcePatternType: sarama.AclPatternLiteral}
+	group := sarama.Resource{ResourceType: sarama.AclResourceGroup, ResourceName: "payments", ResourcePatternType: sarama.AclPatternLiteral}
+	read := sarama.Acl{Principal: "User:Alice", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow}
+	write := sarama.Acl{Principal: "User:Alice", Host: "*", Operation: sarama.AclOperationWrite, PermissionType: sarama.AclPermissionAllow}
+
+	cache := &aclCache{}
+	listed := []*sarama.ResourceAcls{{Resource: topic, Acls: []*sarama.Acl{&read}}}
+	cache.store(listed)
+
+	cache.cacheCreated([]*sarama.AclCreation{
+		{Resource: topic, Acl: write},
+		{Resource: topic, Acl: read},
+		{Resource: group, Acl: read},
+	})
+
+	if len(cache.acls) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(cache.acls))
+	}
+	if len(cache.acls[0].Acls) != 2 {
+		t.Errorf("expected the duplicate creation to be ignored, got %v", cache.acls[0].Acls)
+	}
+	if len(cache.byName["payments"]) != 2 {
+		t.Errorf("expected both resources to be indexed by name, got %v", cache.byName)
+	}
+	// Callers of ListACLs may still hold the old list
+	if len(listed[0].Acls) != 1 {
+		t.Errorf("expected the listed ACLs not to be modified, got %v", listed[0].Acls)
+	}
+
+	cache.cacheDeleted([]*sarama.FilterResponse{
+		{MatchingAcls: []*sarama.MatchingAcl{
+			{Resource: topic, Acl: write},
+			{Resource: group, Acl: read},
+		}},
+		{Err: sarama.ErrSecurityDisabled, MatchingAcls: []*sarama.MatchingAcl{{Resource: topic, Acl: read}}},
+	})
+
+	if len(cache.acls) != 1 || len(cache.acls[0].Acls) != 1 || *cache.acls[0].Acls[0] != read {
+		t.Errorf("expected only the topic Read ACL to be left, got %v", cache.acls)
+	}
+	if len(cache.byName["payments"]) != 1 {
+		t.Errorf("expected the empty group resource to be dropped from the index, got %v", cache.byName)
+	}
+}
+
+func TestACLCache_InvalidCacheIsNotUpdated(t *testing.T) {
+	topic := sarama.Resource{ResourceType: sarama.AclResourceTopic, ResourceName: "payments", ResourcePatternType: sarama.AclPatternLiteral}
+	read := sarama.Acl{Principal: "User:Alice", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow}
+
+	cache := &aclCache{}
+	cache.cacheCreated([]*sarama.AclCreation{{Resource: topic, Acl: read}})
+
+	if cache.valid || cache.acls != nil {
+		t.Errorf("expected an invalid cache to stay empty until it is listed, got %v", cache.acls)
+	}
+}
+
+func TestCreatedACLs(t *testing.T) {
+	creations := []*sarama.AclCreation{
+		{Resource: sarama.Resource{ResourceName: "a"}},
+		{Resource: sarama.Resource{ResourceName: "b"}},
+	}
+	created := createdACLs(creations, []*sarama.AclCreationResponse{
+		{Err: sarama.ErrClusterAuthorizationFailed},
+		{Err: sarama.ErrNoError},
+	})
+
+	if len(created) != 1 || created[0].ResourceName != "b" {
+		t.Errorf("expected only the successful creation, got %v", created)
+	}
+}
@@ -0,0 +1,147 @@
+package kafka
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/IBM/sarama"
+)
+
+// incrementalAlterConfigsKey is the API key of IncrementalAlterConfigs, added
+// in Kafka 2.3
+const incrementalAlterConfigsKey = 44
+
+var brokerIDPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// DescribeBrokerConfig returns the dynamic configs of a broker, or the
+// cluster-wide defaults when brokerID is empty. Sensitive configs, such as
+// keystore passwords, are returned with a nil value.
+func (c *Client) DescribeBrokerConfig(brokerID string) (map[string]*string, error) {
+	log.Printf("[INFO] Describing broker config %s", brokerConfigName(brokerID))
+	admin, err := sarama.NewClusterAdminFromClient(c.client)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := admin.DescribeConfig(sarama.ConfigResource{
+		Type: sarama.BrokerResource,
+		Name: brokerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conf := map[string]*string{}
+	for _, entry := range entries {
+		log.Printf("[TRACE] [%s] %s: %v. Default %v, Source %v, Sensitive %v", brokerConfigName(brokerID), entry.Name, entry.Value, entry.Default, entry.Source, entry.Sensitive)
+		if !isDynamicBrokerConfig(&entry, brokerID) {
+			continue
+		}
+		if entry.Sensitive {
+			conf[entry.Name] = nil
+			continue
+		}
+		v := entry.Value
+		conf[entry.Name] = &v
+	}
+	return conf, nil
+}
+
+// AlterBrokerConfig sets config and removes the deleted keys on a broker, or
+// on the cluster-wide defaults when brokerID is empty. Only
+// IncrementalAlterConfigs is used: the legacy AlterConfigs replaces every
+// dynamic config of the broker, wiping sensitive ones such as listener
+// keystore passwords that cannot be read back and restored.
+func (c *Client) AlterBrokerConfig(brokerID string, config map[string]*string, deleted []string) error {
+	log.Printf("[INFO] Altering broker config %s", brokerConfigName(brokerID))
+	if !c.SupportsIncrementalAlterConfigs() {
+		return fmt.Errorf("broker configs can only be changed with IncrementalAlterConfigs, which is not supported by the cluster")
+	}
+
+	admin, err := sarama.NewClusterAdminFromClient(c.client)
+	if err != nil {
+		return err
+	}
+
+	return admin.IncrementalAlterConfig(sarama.BrokerResource, brokerID, brokerConfigEntries(config, deleted), false)
+}
+
+// isDynamicBrokerConfig reports whether the config was set at runtime on the
+// broker, or on the cluster-wide defaults when brokerID is empty. Like
+// isDefault for topics it relies on the config source, so static configs from
+// server.properties are left out.
+func isDynamicBrokerConfig(entry *sarama.ConfigEntry, brokerID string) bool {
+	if brokerID == "" {
+		return entry.Source == sarama.SourceDynamicDefaultBroker
+	}
+	return entry.Source == sarama.SourceDynamicBroker
+}
+
+func brokerConfigEntries(config map[string]*string, deleted []string) map[string]sarama.IncrementalAlterConfigsEntry {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(config)+len(deleted))
+	for key, value := range config {
+		entries[key] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationSet,
+			Value:     value,
+		}
+	}
+	for _, key := range deleted {
+		entries[key] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationDelete,
+		}
+	}
+	return entries
+}
+
+// brokerConfigName names the broker in logs and errors
+func brokerConfigName(brokerID string) string {
+	if brokerID == "" {
+		return "cluster-wide default broker config"
+	}
+	return "broker " + brokerID
+}
+
+// DescribeBrokerLoggers returns the level of every log4j logger of a broker
+func (c *Client) DescribeBrokerLoggers(brokerID string) (map[string]string, error) {
+	log.Printf("[INFO] Describing loggers of broker %s", brokerID)
+	admin, err := sarama.NewClusterAdminFromClient(c.client)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := admin.DescribeConfig(sarama.ConfigResource{
+		Type: sarama.BrokerLoggerResource,
+		Name: brokerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		levels[entry.Name] = entry.Value
+	}
+	return levels, nil
+}
+
+// AlterBrokerLoggers sets the level of loggers on a broker and unsets the
+// deleted ones, so they inherit the level of their parent again. Kafka only
+// accepts logger changes through IncrementalAlterConfigs.
+func (c *Client) AlterBrokerLoggers(brokerID string, levels map[string]string, deleted []string) error {
+	log.Printf("[INFO] Altering loggers of broker %s: setting %v, unsetting %v", brokerID, levels, deleted)
+	if !c.SupportsIncrementalAlterConfigs() {
+		return fmt.Errorf("broker loggers can only be changed with IncrementalAlterConfigs, which is not supported by the cluster")
+	}
+
+	admin, err := sarama.NewClusterAdminFromClient(c.client)
+	if err != nil {
+		return err
+	}
+
+	config := make(map[string]*string, len(levels))
+	for logger, level := range levels {
+		config[logger] = &level
+	}
+	return admin.IncrementalAlterConfig(sarama.BrokerLoggerResource, brokerID, brokerConfigEntries(config, deleted), false)
+}
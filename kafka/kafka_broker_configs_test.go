@@ -0,0 +1,82 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestIsDynamicBrokerConfig(t *testing.T) {
+	tests := []struct {
+		source   sarama.ConfigSource
+		brokerID string
+		expected bool
+	}{
+		{source: sarama.SourceDynamicBroker, brokerID: "1", expected: true},
+		{source: sarama.SourceDynamicDefaultBroker, brokerID: "1", expected: false},
+		{source: sarama.SourceStaticBroker, brokerID: "1", expected: false},
+		{source: sarama.SourceDefault, brokerID: "1", expected: false},
+		{source: sarama.SourceDynamicDefaultBroker, brokerID: "", expected: true},
+		{source: sarama.SourceDynamicBroker, brokerID: "", expected: false},
+		{source: sarama.SourceStaticBroker, brokerID: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		entry := &sarama.ConfigEntry{Name: "log.cleaner.threads", Source: tt.source}
+		if got := isDynamicBrokerConfig(entry, tt.brokerID); got != tt.expected {
+			t.Errorf("expected source %v for broker %q to be %v, got %v", tt.source, tt.brokerID, tt.expected, got)
+		}
+	}
+}
+
+func TestBrokerConfigEntries(t *testing.T) {
+	threads := "2"
+	entries := brokerConfigEntries(map[string]*string{"log.cleaner.threads": &threads}, []string{"max.connections"})
+
+	expected := map[string]sarama.IncrementalAlterConfigsEntry{
+		"log.cleaner.threads": {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &threads},
+		"max.connections":     {Operation: sarama.IncrementalAlterConfigsOperationDelete},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected %v, got %v", expected, entries)
+	}
+}
+
+func TestFlattenBrokerConfig(t *testing.T) {
+	threads := "2"
+	connections := "1000"
+	found := map[string]*string{
+		"log.cleaner.threads":                     &threads,
+		"max.connections":                         &connections,
+		"listener.name.ssl.ssl.keystore.password": nil,
+		"listener.name.ssl.ssl.key.password":      nil,
+		"listener.name.sasl_ssl.ssl.key.password": nil,
+	}
+	config := map[string]interface{}{
+		"log.cleaner.threads":                "1",
+		"listener.name.ssl.ssl.key.password": "in-config",
+	}
+	sensitiveConfig := map[string]interface{}{
+		"listener.name.ssl.ssl.keystore.password":        "secret",
+		"listener.name.sasl_ssl.ssl.truststore.password": "removed",
+	}
+
+	flatConfig, flatSensitive := flattenBrokerConfig(found, config, sensitiveConfig)
+
+	expectedConfig := map[string]string{
+		"log.cleaner.threads":                "2",
+		"max.connections":                    "1000",
+		"listener.name.ssl.ssl.key.password": "in-config",
+	}
+	if !reflect.DeepEqual(flatConfig, expectedConfig) {
+		t.Errorf("expected config %v, got %v", expectedConfig, flatConfig)
+	}
+
+	expectedSensitive := map[string]string{
+		"listener.name.ssl.ssl.keystore.password": "secret",
+	}
+	if !reflect.DeepEqual(flatSensitive, expectedSensitive) {
+		t.Errorf("expected sensitive_config %v, got %v", expectedSensitive, flatSensitive)
+	}
+}
@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+// metadataKey is the API key of Metadata
+const metadataKey = 3
+
+// Cluster is the identity and broker layout of the cluster
+type Cluster struct {
+	ID           string
+	ControllerID int32
+	Brokers      []ClusterBroker
+}
+
+type ClusterBroker struct {
+	ID   int32
+	Host string
+	Port int32
+	Rack string
+}
+
+// DescribeCluster returns the cluster ID, controller and every broker from a
+// Metadata request. sarama does not implement DescribeCluster (API 60), and
+// Metadata returns the same fields for brokers and KRaft controllers alike.
+//
+// The response also lists every topic and partition, since sarama sends an
+// empty topic list as null, which means all topics. This is a single request
+// per read, but it grows with the number of partitions. client.Brokers()
+// cannot be used instead, because sarama does not keep the cluster ID.
+func (c *Client) DescribeCluster() (*Cluster, error) {
+	log.Printf("[INFO] Describing cluster")
+	broker, err := c.client.Controller()
+	if err != nil {
+		return nil, err
+	}
+
+	request := sarama.NewMetadataRequest(c.kafkaConfig.Version, nil)
+	request.Version = int16(c.versionForKey(metadataKey, int(request.Version)))
+
+	res, err := broker.GetMetadata(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterFromMetadata(res)
+}
+
+func clusterFromMetadata(res *sarama.MetadataResponse) (*Cluster, error) {
+	cluster := &Cluster{
+		ControllerID: res.ControllerID,
+		Brokers:      make([]ClusterBroker, 0, len(res.Brokers)),
+	}
+	if res.ClusterID != nil {
+		cluster.ID = *res.ClusterID
+	}
+
+	for _, b := range res.Brokers {
+		host, port, err := net.SplitHostPort(b.Addr())
+		if err != nil {
+			return nil, fmt.Errorf("broker %d has an invalid address: %w", b.ID(), err)
+		}
+		p, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("broker %d has an invalid port: %w", b.ID(), err)
+		}
+		cluster.Brokers = append(cluster.Brokers, ClusterBroker{
+			ID:   b.ID(),
+			Host: host,
+			Port: int32(p),
+			Rack: b.Rack(),
+		})
+	}
+	sort.Slice(cluster.Brokers, func(i, j int) bool {
+		return cluster.Brokers[i].ID < cluster.Brokers[j].ID
+	})
+
+	return cluster, nil
+}
+
+// Racks returns the distinct racks of the brokers, sorted. Brokers without a
+// rack are left out.
+func (c Cluster) Racks() []string {
+	seen := map[string]bool{}
+	racks := []string{}
+	for _, b := range c.Brokers {
+		if b.Rack != "" && !seen[b.Rack] {
+			seen[b.Rack] = true
+			racks = append(racks, b.Rack)
+		}
+	}
+	sort.Strings(racks)
+	return racks
+}
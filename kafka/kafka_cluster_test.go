@@ -0,0 +1,58 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestClusterFromMetadata(t *testing.T) {
+	clusterID := "MkU3OEVBNTcwNTJENDM2Qk"
+	res := &sarama.MetadataResponse{ClusterID: &clusterID, ControllerID: 2}
+	res.AddBroker("kafka3:9092", 3)
+	res.AddBroker("kafka1:9092", 1)
+	res.AddBroker("[::1]:9093", 2)
+
+	cluster, err := clusterFromMetadata(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Cluster{
+		ID:           clusterID,
+		ControllerID: 2,
+		Brokers: []ClusterBroker{
+			{ID: 1, Host: "kafka1", Port: 9092},
+			{ID: 2, Host: "::1", Port: 9093},
+			{ID: 3, Host: "kafka3", Port: 9092},
+		},
+	}
+	if !reflect.DeepEqual(cluster, expected) {
+		t.Errorf("expected %+v, got %+v", expected, cluster)
+	}
+
+	res.AddBroker("kafka4", 4)
+	if _, err := clusterFromMetadata(res); err == nil {
+		t.Error("expected an address without a port to fail")
+	}
+}
+
+func TestClusterRacks(t *testing.T) {
+	cluster := Cluster{
+		Brokers: []ClusterBroker{
+			{ID: 1, Rack: "eu-west-1b"},
+			{ID: 2, Rack: "eu-west-1a"},
+			{ID: 3, Rack: "eu-west-1b"},
+			{ID: 4},
+		},
+	}
+
+	expected := []string{"eu-west-1a", "eu-west-1b"}
+	if racks := cluster.Racks(); !reflect.DeepEqual(racks, expected) {
+		t.Errorf("expected %v, got %v", expected, racks)
+	}
+	if racks := (Cluster{}).Racks(); len(racks) != 0 {
+		t.Errorf("expected no racks, got %v", racks)
+	}
+}
@@ -0,0 +1,335 @@
+package kafka
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const userPrincipalPrefix = "User:"
+
+// rfc2253Keywords are the attribute types Java's X500Principal names in RFC 2253
+// output. Every other type is written as a dotted OID with a hex encoded value.
+var rfc2253Keywords = map[string]string{
+	"2.5.4.3":                    "CN",
+	"2.5.4.6":                    "C",
+	"2.5.4.7":                    "L",
+	"2.5.4.8":                    "ST",
+	"2.5.4.9":                    "STREET",
+	"2.5.4.10":                   "O",
+	"2.5.4.11":                   "OU",
+	"0.9.2342.19200300.100.1.1":  "UID",
+	"0.9.2342.19200300.100.1.25": "DC",
+}
+
+type rawAttributeTypeAndValue struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+// rawRDNSET keeps the DER of each value, which the hex form needs
+type rawRDNSET []rawAttributeTypeAndValue
+
+type rawRDNSequence []rawRDNSET
+
+// certificateDistinguishedName formats the subject of a certificate like Java's
+// X500Principal.getName(), which is what Kafka applies
+// ssl.principal.mapping.rules to
+func certificateDistinguishedName(cert *x509.Certificate) (string, error) {
+	var seq rawRDNSequence
+	rest, err := asn1.Unmarshal(cert.RawSubject, &seq)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse certificate subject: %w", err)
+	}
+	if len(rest) > 0 {
+		return "", errors.New("trailing data after certificate subject")
+	}
+
+	rdns := make([]string, 0, len(seq))
+	// RFC 2253 lists the most specific RDN first
+	for _, set := range slices.Backward(seq) {
+		attrs := make([]string, 0, len(set))
+		for _, attr := range set {
+			attrs = append(attrs, formatRFC2253Attribute(attr))
+		}
+		rdns = append(rdns, strings.Join(attrs, "+"))
+	}
+	return strings.Join(rdns, ","), nil
+}
+
+func formatRFC2253Attribute(attr rawAttributeTypeAndValue) string {
+	oid := attr.Type.String()
+	if keyword, ok := rfc2253Keywords[oid]; ok {
+		var value string
+		if _, err := asn1.Unmarshal(attr.Value.FullBytes, &value); err == nil {
+			return keyword + "=" + escapeRFC2253(value)
+		}
+	}
+	return oid + "=#" + hex.EncodeToString(attr.Value.FullBytes)
+}
+
+func escapeRFC2253(value string) string {
+	var b strings.Builder
+	for i, r := range value {
+		switch {
+		case strings.ContainsRune(",=+<>#;\"\\", r):
+			b.WriteRune('\\')
+		case r == ' ' && (i == 0 || i == len(value)-1):
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// principalMappingRule is a rule of ssl.principal.mapping.rules
+type principalMappingRule struct {
+	isDefault   bool
+	full        *regexp.Regexp
+	pattern     *regexp.Regexp
+	replacement string
+	toLower     bool
+	toUpper     bool
+}
+
+// apply returns the mapped name and whether the rule matched the DN
+func (r principalMappingRule) apply(dn string) (string, bool) {
+	if r.isDefault {
+		return dn, true
+	}
+	if !r.full.MatchString(dn) {
+		return "", false
+	}
+
+	name := r.pattern.ReplaceAllString(dn, r.replacement)
+	if r.toLower {
+		name = strings.ToLower(name)
+	} else if r.toUpper {
+		name = strings.ToUpper(name)
+	}
+	return name, true
+}
+
+// parsePrincipalMappingRules parses ssl.principal.mapping.rules, a comma
+// separated list of DEFAULT and RULE:pattern/replacement/[LU] entries. Patterns
+// use Go's regular expression syntax, which lacks Java's lookarounds and
+// backreferences.
+func parsePrincipalMappingRules(rules string) ([]principalMappingRule, error) {
+	parsed := []principalMappingRule{}
+	rest := rules
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+
+		var rule principalMappingRule
+		switch {
+		case strings.HasPrefix(rest, "DEFAULT"):
+			rule.isDefault = true
+			rest = rest[len("DEFAULT"):]
+		case strings.HasPrefix(rest, "RULE:"):
+			pattern, afterPattern, ok := cutUnescaped(rest[len("RULE:"):], '/')
+			if !ok {
+				return nil, fmt.Errorf("invalid rule %q: expected RULE:pattern/replacement/[LU]", rest)
+			}
+			replacement, afterReplacement, ok := cutUnescaped(afterPattern, '/')
+			if !ok {
+				return nil, fmt.Errorf("invalid rule %q: expected RULE:pattern/replacement/[LU]", rest)
+			}
+
+			var err error
+			if rule.pattern, err = regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("invalid rule pattern %q: %w", pattern, err)
+			}
+			rule.full = regexp.MustCompile("^(?:" + pattern + ")$")
+			rule.replacement = javaReplacementToGo(replacement)
+
+			rest = afterReplacement
+			switch {
+			case strings.HasPrefix(rest, "L"):
+				rule.toLower = true
+				rest = rest[1:]
+			case strings.HasPrefix(rest, "U"):
+				rule.toUpper = true
+				rest = rest[1:]
+			}
+		default:
+			return nil, fmt.Errorf("invalid rule %q: expected DEFAULT or RULE:pattern/replacement/[LU]", rest)
+		}
+
+		// Like Kafka, ignore anything else up to the next rule
+		if i := strings.IndexByte(rest, ','); i >= 0 {
+			rest = rest[i+1:]
+		} else {
+			rest = ""
+		}
+		parsed = append(parsed, rule)
+	}
+
+	if len(parsed) == 0 {
+		parsed = append(parsed, principalMappingRule{isDefault: true})
+	}
+	return parsed, nil
+}
+
+// cutUnescaped splits s around the first sep not escaped by a backslash,
+// unescaping escaped separators
+func cutUnescaped(s string, sep byte) (string, string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == sep:
+			b.WriteByte(sep)
+			i++
+		case s[i] == '\\' && i+1 < len(s):
+			b.WriteByte(s[i])
+			b.WriteByte(s[i+1])
+			i++
+		case s[i] == sep:
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", false
+}
+
+// javaReplacementToGo converts a java.util.regex replacement, where $1 is a
+// group and \x a literal x, to a regexp.Expand template
+func javaReplacementToGo(replacement string) string {
+	var b strings.Builder
+	for i := 0; i < len(replacement); i++ {
+		c := replacement[i]
+		switch {
+		case c == '\\' && i+1 < len(replacement):
+			i++
+			if replacement[i] == '$' {
+				b.WriteString("$$")
+			} else {
+				b.WriteByte(replacement[i])
+			}
+		case c == '$' && i+1 < len(replacement) && replacement[i+1] >= '0' && replacement[i+1] <= '9':
+			j := i + 1
+			for j < len(replacement) && replacement[j] >= '0' && replacement[j] <= '9' {
+				j++
+			}
+			b.WriteString("${" + replacement[i+1:j] + "}")
+			i = j - 1
+		case c == '$':
+			b.WriteString("$$")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// mapPrincipalName applies the mapping rules to a DN like Kafka's
+// SslPrincipalMapper, using the first rule that matches
+func mapPrincipalName(dn string, rules []principalMappingRule) (string, error) {
+	for _, rule := range rules {
+		if name, ok := rule.apply(dn); ok {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no principal mapping rule matches %q", dn)
+}
+
+// normalizePrincipal rewrites a User: principal holding a distinguished name in
+// the form Kafka derives from certificates: no spaces around separators and
+// upper-case attribute keywords. Kafka compares principals as exact strings, so
+// this is applied once before the ACL is created. RDNs are never reordered,
+// since certificates may list their subject in either order. Principals that
+// are not DNs made of RFC 2253 keywords or OIDs are returned unchanged.
+func normalizePrincipal(principal string) string {
+	name, ok := strings.CutPrefix(principal, userPrincipalPrefix)
+	if !ok {
+		return principal
+	}
+
+	rdns, ok := parseDN(name)
+	if !ok {
+		return principal
+	}
+
+	formatted := make([]string, 0, len(rdns))
+	for _, rdn := range rdns {
+		attrs := make([]string, 0, len(rdn))
+		for _, attr := range rdn {
+			attrs = append(attrs, attr[0]+"="+attr[1])
+		}
+		formatted = append(formatted, strings.Join(attrs, "+"))
+	}
+	return userPrincipalPrefix + strings.Join(formatted, ",")
+}
+
+var dnAttributeOIDPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)+$`)
+
+// dnAttributeType returns the attribute type as Java's X500Principal writes
+// it, and false for types it never writes, such as the app in User:app=prod
+func dnAttributeType(typ string) (string, bool) {
+	if dnAttributeOIDPattern.MatchString(typ) {
+		return typ, true
+	}
+	keyword := strings.ToUpper(typ)
+	for _, known := range rfc2253Keywords {
+		if keyword == known {
+			return keyword, true
+		}
+	}
+	return "", false
+}
+
+// parseDN splits a distinguished name into RDNs of type/value pairs, keeping
+// escaped characters in values as written
+func parseDN(dn string) ([][][2]string, bool) {
+	rdns := [][][2]string{}
+	for _, rdn := range splitUnescaped(dn, ',') {
+		attrs := [][2]string{}
+		for _, attr := range splitUnescaped(rdn, '+') {
+			typ, value, ok := strings.Cut(attr, "=")
+			if !ok {
+				return nil, false
+			}
+			typ, ok = dnAttributeType(strings.TrimSpace(typ))
+			if !ok {
+				return nil, false
+			}
+			attrs = append(attrs, [2]string{typ, trimDNValue(value)})
+		}
+		rdns = append(rdns, attrs)
+	}
+	return rdns, true
+}
+
+// splitUnescaped splits s around every sep not escaped by a backslash
+func splitUnescaped(s string, sep byte) []string {
+	parts := []string{}
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// trimDNValue trims surrounding spaces that are not escaped
+func trimDNValue(value string) string {
+	value = strings.TrimLeft(value, " ")
+	for strings.HasSuffix(value, " ") && !strings.HasSuffix(value, "\\ ") {
+		value = value[:len(value)-1]
+	}
+	return value
+}
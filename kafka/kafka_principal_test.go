@@ -0,0 +1,167 @@
+package kafka
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func testCertificate(t *testing.T, subject pkix.Name) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestCertificateDistinguishedName(t *testing.T) {
+	tests := []struct {
+		subject  pkix.Name
+		expected string
+	}{
+		{
+			subject: pkix.Name{
+				CommonName:         "alice",
+				OrganizationalUnit: []string{"ServiceUsers"},
+				Organization:       []string{"Example, Inc."},
+				Country:            []string{"US"},
+			},
+			expected: `CN=alice,OU=ServiceUsers,O=Example\, Inc.,C=US`,
+		},
+		{
+			subject:  pkix.Name{CommonName: "a=b+c"},
+			expected: `CN=a\=b\+c`,
+		},
+		{
+			subject: pkix.Name{
+				CommonName:   "svc",
+				SerialNumber: "42",
+			},
+			expected: "2.5.4.5=#13023432,CN=svc",
+		},
+		{
+			subject: pkix.Name{
+				CommonName: "svc",
+				ExtraNames: []pkix.AttributeTypeAndValue{
+					{Type: asn1.ObjectIdentifier{0, 9, 2342, 19200300, 100, 1, 25}, Value: "example"},
+				},
+			},
+			expected: "DC=example,CN=svc",
+		},
+	}
+
+	for _, tt := range tests {
+		dn, err := certificateDistinguishedName(testCertificate(t, tt.subject))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dn != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, dn)
+		}
+	}
+}
+
+func TestMapPrincipalName(t *testing.T) {
+	tests := []struct {
+		rules    string
+		dn       string
+		expected string
+		err      bool
+	}{
+		{rules: "", dn: "CN=alice,O=Example", expected: "CN=alice,O=Example"},
+		{rules: "DEFAULT", dn: "CN=alice,O=Example", expected: "CN=alice,O=Example"},
+		{
+			rules:    "RULE:^CN=(.*?),OU=ServiceUsers.*$/$1/L, DEFAULT",
+			dn:       "CN=Alice,OU=ServiceUsers,O=Example",
+			expected: "alice",
+		},
+		{
+			rules:    "RULE:^CN=(.*?),OU=ServiceUsers.*$/$1/L, DEFAULT",
+			dn:       "CN=Bob,OU=Humans,O=Example",
+			expected: "CN=Bob,OU=Humans,O=Example",
+		},
+		{
+			rules:    "RULE:^CN=([a-z]+),O=(.*)$/$2\\/$1/U",
+			dn:       "CN=alice,O=example",
+			expected: "EXAMPLE/ALICE",
+		},
+		{
+			rules: "RULE:^CN=(.*),OU=ServiceUsers$/$1/",
+			dn:    "CN=alice,OU=Humans",
+			err:   true,
+		},
+		{rules: "RULE:^CN=(.*)$", err: true},
+		{rules: "NONSENSE", err: true},
+	}
+
+	for _, tt := range tests {
+		rules, err := parsePrincipalMappingRules(tt.rules)
+		var name string
+		if err == nil {
+			name, err = mapPrincipalName(tt.dn, rules)
+		}
+		if tt.err {
+			if err == nil {
+				t.Errorf("expected rules %q to fail for %q, got %q", tt.rules, tt.dn, name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for rules %q: %v", tt.rules, err)
+			continue
+		}
+		if name != tt.expected {
+			t.Errorf("expected rules %q to map %q to %q, got %q", tt.rules, tt.dn, tt.expected, name)
+		}
+	}
+}
+
+func TestNormalizePrincipal(t *testing.T) {
+	tests := []struct {
+		principal string
+		expected  string
+	}{
+		{"User:alice", "User:alice"},
+		{"User:*", "User:*"},
+		{"Group:CN=alice, O=Example", "Group:CN=alice, O=Example"},
+		{"User:CN=alice,O=Example", "User:CN=alice,O=Example"},
+		{"User:CN = alice, OU = Service Users, O = Example", "User:CN=alice,OU=Service Users,O=Example"},
+		{"User:cn=alice,o=Example", "User:CN=alice,O=Example"},
+		{"User:C = US, O = Example, CN = alice", "User:C=US,O=Example,CN=alice"},
+		{"User:DC=com,DC=example,CN=alice", "User:DC=com,DC=example,CN=alice"},
+		{"User:app=prod", "User:app=prod"},
+		{"User:CN=alice, team=payments", "User:CN=alice, team=payments"},
+		{"User:cn = alice, 2.5.4.5 = #13023432", "User:CN=alice,2.5.4.5=#13023432"},
+		{`User:CN=Example\, Inc.,C=US`, `User:CN=Example\, Inc.,C=US`},
+		{`User:CN=trailing\ ,O=Example`, `User:CN=trailing\ ,O=Example`},
+		{"User:CN=alice + UID=42, O=Example", "User:CN=alice+UID=42,O=Example"},
+		{"User:2.5.4.5=#13023432,CN=svc", "User:2.5.4.5=#13023432,CN=svc"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizePrincipal(tt.principal); got != tt.expected {
+			t.Errorf("expected %q to normalise to %q, got %q", tt.principal, tt.expected, got)
+		}
+	}
+}
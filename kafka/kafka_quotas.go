@@ -3,6 +3,9 @@ package kafka
 import (
 	"fmt"
 	"log"
+	"math"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/IBM/sarama"
@@ -20,10 +23,16 @@ type QuotaOp struct {
 	Remove bool
 }
 
+// QuotaEntity is a single component of a quota entity, e.g. user=alice.
+// An empty Name refers to the default entity of that type.
+type QuotaEntity struct {
+	Type string
+	Name string
+}
+
 type Quota struct {
-	EntityType string
-	EntityName string
-	Ops        []QuotaOp
+	Entity []QuotaEntity
+	Ops    []QuotaOp
 }
 
 func (a Quota) String() string {
@@ -32,11 +41,295 @@ func (a Quota) String() string {
 
 const entityDefault = "entity-default"
 
+// ID joins each entity component as name|type, so a single component entity
+// keeps the historical name|type format and a user + client-id entity becomes
+// user-name|user|client-name|client-id.
 func (a Quota) ID() string {
-	if a.EntityName == "" {
-		return strings.Join([]string{entityDefault, a.EntityType}, "|")
+	parts := make([]string, 0, 2*len(a.Entity))
+	for _, e := range a.Entity {
+		name := e.Name
+		if name == "" {
+			name = entityDefault
+		}
+		parts = append(parts, name, e.Type)
+	}
+	return strings.Join(parts, "|")
+}
+
+// parseQuotaID is the inverse of Quota.ID
+func parseQuotaID(id string) ([]QuotaEntity, error) {
+	parts := strings.Split(id, "|")
+	if len(parts)%2 != 0 {
+		return nil, fmt.Errorf("expected format is name|type, optionally repeated for composite entities (e.g. alice|user|app|client-id) - got %v segments", len(parts))
+	}
+
+	entity := make([]QuotaEntity, 0, len(parts)/2)
+	for i := 0; i < len(parts); i += 2 {
+		name := parts[i]
+		if name == entityDefault {
+			name = ""
+		}
+		entity = append(entity, QuotaEntity{
+			Type: parts[i+1],
+			Name: name,
+		})
+	}
+
+	if err := validateQuotaEntity(entity); err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+// validateQuotaEntity checks that the components form an entity Kafka accepts:
+// a single user, client-id or ip, or the user + client-id combination.
+func validateQuotaEntity(entity []QuotaEntity) error {
+	if len(entity) == 0 {
+		return fmt.Errorf("a quota entity needs at least one component")
+	}
+
+	seen := map[string]bool{}
+	for _, e := range entity {
+		switch e.Type {
+		case "user", "client-id", "ip":
+		default:
+			return fmt.Errorf("unknown quota entity type '%s': can only be \"user\", \"client-id\" or \"ip\"", e.Type)
+		}
+		if seen[e.Type] {
+			return fmt.Errorf("quota entity type '%s' can only be used once per entity", e.Type)
+		}
+		seen[e.Type] = true
+	}
+
+	if seen["ip"] && len(entity) > 1 {
+		return fmt.Errorf("quota entity type 'ip' cannot be combined with other entity types")
+	}
+
+	return nil
+}
+
+func quotaEntityComponents(entity []QuotaEntity) []sarama.QuotaEntityComponent {
+	components := make([]sarama.QuotaEntityComponent, 0, len(entity))
+	for _, e := range entity {
+		if e.Name == "" {
+			components = append(components, sarama.QuotaEntityComponent{
+				EntityType: sarama.QuotaEntityType(e.Type),
+				MatchType:  sarama.QuotaMatchDefault,
+			})
+		} else {
+			components = append(components, sarama.QuotaEntityComponent{
+				EntityType: sarama.QuotaEntityType(e.Type),
+				MatchType:  sarama.QuotaMatchExact,
+				Name:       e.Name,
+			})
+		}
+	}
+	return components
+}
+
+func quotaFilterComponents(entity []QuotaEntity) []sarama.QuotaFilterComponent {
+	components := make([]sarama.QuotaFilterComponent, 0, len(entity))
+	for _, e := range entity {
+		if e.Name == "" {
+			components = append(components, sarama.QuotaFilterComponent{
+				EntityType: sarama.QuotaEntityType(e.Type),
+				MatchType:  sarama.QuotaMatchDefault,
+			})
+		} else {
+			components = append(components, sarama.QuotaFilterComponent{
+				EntityType: sarama.QuotaEntityType(e.Type),
+				MatchType:  sarama.QuotaMatchExact,
+				Match:      e.Name,
+			})
+		}
+	}
+	return components
+}
+
+// matchesQuotaEntity reports whether the components returned by Kafka describe
+// exactly the wanted entity, regardless of the order Kafka returns them in.
+func matchesQuotaEntity(components []sarama.QuotaEntityComponent, entity []QuotaEntity) bool {
+	if len(components) != len(entity) {
+		return false
+	}
+
+	names := make(map[string]string, len(components))
+	for _, c := range components {
+		names[string(c.EntityType)] = c.Name
+	}
+
+	for _, e := range entity {
+		name, ok := names[e.Type]
+		if !ok || name != e.Name {
+			return false
+		}
+	}
+	return true
+}
+
+type quotaKeySpec struct {
+	// entityTypes the key can be set on; an entity qualifies when any of its
+	// components has one of these types
+	entityTypes []string
+	integer     bool
+	max         float64
+}
+
+// quotaKeys mirrors the client quota configs Kafka accepts, see
+// https://kafka.apache.org/documentation/#quotas
+var quotaKeys = map[string]quotaKeySpec{
+	"producer_byte_rate": {
+		entityTypes: []string{"user", "client-id"},
+		integer:     true,
+		max:         math.MaxInt64,
+	},
+	"consumer_byte_rate": {
+		entityTypes: []string{"user", "client-id"},
+		integer:     true,
+		max:         math.MaxInt64,
+	},
+	"request_percentage": {
+		entityTypes: []string{"user", "client-id"},
+		max:         math.MaxFloat64,
+	},
+	"controller_mutation_rate": {
+		entityTypes: []string{"user", "client-id"},
+		max:         math.MaxFloat64,
+	},
+	"connection_creation_rate": {
+		entityTypes: []string{"ip"},
+		integer:     true,
+		max:         math.MaxInt32,
+	},
+}
+
+// validQuotaKeys lists the keys that can be set on an entity
+func validQuotaKeys(entity []QuotaEntity) []string {
+	keys := []string{}
+	for key, spec := range quotaKeys {
+		for _, e := range entity {
+			if slices.Contains(spec.entityTypes, e.Type) {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateQuotaConfig checks every key is a known quota for the entity type and
+// that its value is in range, returning one error per problem found.
+func validateQuotaConfig(entity []QuotaEntity, config map[string]float64) []error {
+	var errs []error
+	valid := validQuotaKeys(entity)
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := config[key]
+		spec, ok := quotaKeys[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("config: unknown quota '%s', valid quotas for %s are %s", key, Quota{Entity: entity}, strings.Join(valid, ", ")))
+			continue
+		}
+		if !slices.Contains(valid, key) {
+			errs = append(errs, fmt.Errorf("config: quota '%s' can only be set on %s entities, valid quotas for %s are %s", key, strings.Join(spec.entityTypes, " or "), Quota{Entity: entity}, strings.Join(valid, ", ")))
+			continue
+		}
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("config: quota '%s' must not be negative, got %v", key, value))
+			continue
+		}
+		if value > spec.max {
+			errs = append(errs, fmt.Errorf("config: quota '%s' must be at most %v, got %v", key, spec.max, value))
+			continue
+		}
+		if spec.integer && value != math.Trunc(value) {
+			errs = append(errs, fmt.Errorf("config: quota '%s' must be a whole number, got %v", key, value))
+		}
+	}
+
+	return errs
+}
+
+// QuotaFilter restricts DescribeQuotas to entities that have a component of
+// EntityType. MatchType is one of "any", "exact" (with Name) or "default".
+type QuotaFilter struct {
+	EntityType string
+	MatchType  string
+	Name       string
+}
+
+const (
+	quotaMatchAny     = "any"
+	quotaMatchExact   = "exact"
+	quotaMatchDefault = "default"
+)
+
+func (f QuotaFilter) component() (sarama.QuotaFilterComponent, error) {
+	component := sarama.QuotaFilterComponent{
+		EntityType: sarama.QuotaEntityType(f.EntityType),
+	}
+
+	switch f.MatchType {
+	case quotaMatchAny:
+		component.MatchType = sarama.QuotaMatchAny
+	case quotaMatchDefault:
+		component.MatchType = sarama.QuotaMatchDefault
+	case quotaMatchExact:
+		if f.Name == "" {
+			return component, fmt.Errorf("a name is required to match %s entities exactly", f.EntityType)
+		}
+		component.MatchType = sarama.QuotaMatchExact
+		component.Match = f.Name
+	default:
+		return component, fmt.Errorf("unknown quota match type '%s': can only be \"%s\", \"%s\" or \"%s\"", f.MatchType, quotaMatchAny, quotaMatchExact, quotaMatchDefault)
+	}
+
+	return component, nil
+}
+
+// quotaEntityOrder is the order Kafka itself uses when printing entities
+var quotaEntityOrder = map[string]int{
+	"user":      0,
+	"client-id": 1,
+	"ip":        2,
+}
+
+func quotaFromEntry(e sarama.DescribeClientQuotasEntry) Quota {
+	entity := make([]QuotaEntity, 0, len(e.Entity))
+	for _, component := range e.Entity {
+		entity = append(entity, QuotaEntity{
+			Type: string(component.EntityType),
+			Name: component.Name,
+		})
+	}
+	sort.SliceStable(entity, func(i, j int) bool {
+		return quotaEntityOrder[entity[i].Type] < quotaEntityOrder[entity[j].Type]
+	})
+
+	ops := make([]QuotaOp, 0, len(e.Values))
+	for k, v := range e.Values {
+		ops = append(ops, QuotaOp{
+			Key:    k,
+			Value:  v,
+			Remove: false,
+		})
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].Key < ops[j].Key
+	})
+
+	return Quota{
+		Entity: entity,
+		Ops:    ops,
 	}
-	return strings.Join([]string{a.EntityName, a.EntityType}, "|")
 }
 
 func (c *Client) AlterQuota(quota Quota, validateOnly bool) error {
@@ -46,20 +339,6 @@ func (c *Client) AlterQuota(quota Quota, validateOnly bool) error {
 		return err
 	}
 
-	var entity sarama.QuotaEntityComponent
-	if quota.EntityName == "" {
-		entity = sarama.QuotaEntityComponent{
-			EntityType: sarama.QuotaEntityType(quota.EntityType),
-			MatchType:  sarama.QuotaMatchDefault,
-		}
-	} else {
-		entity = sarama.QuotaEntityComponent{
-			EntityType: sarama.QuotaEntityType(quota.EntityType),
-			MatchType:  sarama.QuotaMatchExact,
-			Name:       quota.EntityName,
-		}
-	}
-
 	configs := quota.Ops
 
 	ops := []sarama.ClientQuotasOp{}
@@ -72,7 +351,7 @@ func (c *Client) AlterQuota(quota Quota, validateOnly bool) error {
 	}
 
 	entry := sarama.AlterClientQuotasEntry{
-		Entity: []sarama.QuotaEntityComponent{entity},
+		Entity: quotaEntityComponents(quota.Entity),
 		Ops:    ops,
 	}
 
@@ -98,29 +377,15 @@ func (c *Client) AlterQuota(quota Quota, validateOnly bool) error {
 	return nil
 }
 
-func (c *Client) DescribeQuota(entityType string, entityName string) (*Quota, error) {
+func (c *Client) DescribeQuota(entity []QuotaEntity) (*Quota, error) {
 	log.Printf("[INFO] Describing Quota")
 	broker, err := c.client.Controller()
 	if err != nil {
 		return nil, err
 	}
 
-	var entity sarama.QuotaFilterComponent
-	if entityName == "" {
-		entity = sarama.QuotaFilterComponent{
-			EntityType: sarama.QuotaEntityType(entityType),
-			MatchType:  sarama.QuotaMatchDefault,
-		}
-	} else {
-		entity = sarama.QuotaFilterComponent{
-			EntityType: sarama.QuotaEntityType(entityType),
-			MatchType:  sarama.QuotaMatchExact,
-			Match:      entityName,
-		}
-	}
-
 	request := &sarama.DescribeClientQuotasRequest{
-		Components: []sarama.QuotaFilterComponent{entity},
+		Components: quotaFilterComponents(entity),
 		Strict:     true,
 	}
 
@@ -138,29 +403,63 @@ func (c *Client) DescribeQuota(entityType string, entityName string) (*Quota, er
 		}
 	}
 
-	if len(quotaR.Entries) < 1 {
-		return nil, QuotaMissingError{msg: fmt.Sprintf("%s could not be found", entityName)}
-	}
-
-	res := []Quota{}
 	for _, e := range quotaR.Entries {
-		ops := []QuotaOp{}
-		for k, v := range e.Values {
-			ops = append(ops, QuotaOp{
-				Key:    k,
-				Value:  v,
-				Remove: false,
-			})
+		if !matchesQuotaEntity(e.Entity, entity) {
+			continue
 		}
-		for _, entry := range e.Entity {
-			q := Quota{
-				EntityType: string(entry.EntityType),
-				EntityName: entry.Name,
-				Ops:        ops,
-			}
-			res = append(res, q)
+
+		// Keep the requested component order so state matches the configuration
+		q := quotaFromEntry(e)
+		q.Entity = entity
+		return &q, nil
+	}
+
+	return nil, QuotaMissingError{msg: fmt.Sprintf("%s could not be found", Quota{Entity: entity})}
+}
+
+// DescribeQuotas lists every quota whose entity matches all of the filters.
+// Unlike DescribeQuota the lookup is not strict, so entities with additional
+// components are returned too; no filters returns every quota on the cluster.
+func (c *Client) DescribeQuotas(filters []QuotaFilter) ([]Quota, error) {
+	log.Printf("[INFO] Describing Quotas %v", filters)
+	broker, err := c.client.Controller()
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]sarama.QuotaFilterComponent, 0, len(filters))
+	for _, f := range filters {
+		component, err := f.component()
+		if err != nil {
+			return nil, err
 		}
+		components = append(components, component)
+	}
+
+	request := &sarama.DescribeClientQuotasRequest{
+		Components: components,
+		Strict:     false,
+	}
+
+	log.Printf("[TRACE] Describe Quotas Request %v", request)
+	quotaR, err := broker.DescribeClientQuotas(request)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[TRACE] ThrottleTime: %d", quotaR.ThrottleTime)
+
+	if quotaR.ErrorCode != sarama.ErrNoError {
+		return nil, fmt.Errorf("error describing quotas %s", quotaR.ErrorCode)
+	}
+
+	res := make([]Quota, 0, len(quotaR.Entries))
+	for _, e := range quotaR.Entries {
+		res = append(res, quotaFromEntry(e))
 	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID() < res[j].ID()
+	})
 
-	return &res[0], err
+	return res, nil
 }
@@ -0,0 +1,230 @@
+package kafka
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestQuotaID(t *testing.T) {
+	tests := []struct {
+		entity   []QuotaEntity
+		expected string
+	}{
+		{
+			entity:   []QuotaEntity{{Type: "client-id", Name: "app"}},
+			expected: "app|client-id",
+		},
+		{
+			entity:   []QuotaEntity{{Type: "user"}},
+			expected: "entity-default|user",
+		},
+		{
+			entity:   []QuotaEntity{{Type: "user", Name: "alice"}, {Type: "client-id", Name: "app"}},
+			expected: "alice|user|app|client-id",
+		},
+		{
+			entity:   []QuotaEntity{{Type: "user", Name: "alice"}, {Type: "client-id"}},
+			expected: "alice|user|entity-default|client-id",
+		},
+	}
+
+	for _, test := range tests {
+		id := Quota{Entity: test.entity}.ID()
+		if id != test.expected {
+			t.Errorf("expected %s, got %s", test.expected, id)
+		}
+	}
+}
+
+func TestParseQuotaID(t *testing.T) {
+	ids := []string{
+		"app|client-id",
+		"entity-default|user",
+		"alice|user|app|client-id",
+		"alice|user|entity-default|client-id",
+		"203.0.113.0|ip",
+	}
+	for _, id := range ids {
+		entity, err := parseQuotaID(id)
+		if err != nil {
+			t.Errorf("unexpected error parsing %s: %s", id, err)
+			continue
+		}
+		if roundTrip := (Quota{Entity: entity}).ID(); roundTrip != id {
+			t.Errorf("expected %s, got %s", id, roundTrip)
+		}
+	}
+
+	entity, err := parseQuotaID("entity-default|user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entity[0].Name != "" {
+		t.Errorf("expected entity-default to map to an empty name, got %s", entity[0].Name)
+	}
+
+	invalid := []string{
+		"app",
+		"app|client-id|alice",
+		"app|group",
+		"alice|user|bob|user",
+	}
+	for _, id := range invalid {
+		if _, err := parseQuotaID(id); err == nil {
+			t.Errorf("expected %s to be rejected", id)
+		}
+	}
+}
+
+func TestValidateQuotaEntity(t *testing.T) {
+	valid := [][]QuotaEntity{
+		{{Type: "user", Name: "alice"}},
+		{{Type: "ip"}},
+		{{Type: "user", Name: "alice"}, {Type: "client-id", Name: "app"}},
+		{{Type: "client-id"}, {Type: "user"}},
+	}
+	for _, entity := range valid {
+		if err := validateQuotaEntity(entity); err != nil {
+			t.Errorf("expected %v to be valid, got %s", entity, err)
+		}
+	}
+
+	invalid := [][]QuotaEntity{
+		{},
+		{{Type: "group"}},
+		{{Type: "user", Name: "alice"}, {Type: "user", Name: "bob"}},
+		{{Type: "ip", Name: "10.0.0.1"}, {Type: "user", Name: "alice"}},
+	}
+	for _, entity := range invalid {
+		if err := validateQuotaEntity(entity); err == nil {
+			t.Errorf("expected %v to be invalid", entity)
+		}
+	}
+}
+
+func TestMatchesQuotaEntity(t *testing.T) {
+	entity := []QuotaEntity{{Type: "user", Name: "alice"}, {Type: "client-id"}}
+
+	reordered := []sarama.QuotaEntityComponent{
+		{EntityType: sarama.QuotaEntityClientID, MatchType: sarama.QuotaMatchDefault},
+		{EntityType: sarama.QuotaEntityUser, MatchType: sarama.QuotaMatchExact, Name: "alice"},
+	}
+	if !matchesQuotaEntity(reordered, entity) {
+		t.Errorf("expected %v to match %v", reordered, entity)
+	}
+
+	partial := []sarama.QuotaEntityComponent{
+		{EntityType: sarama.QuotaEntityUser, MatchType: sarama.QuotaMatchExact, Name: "alice"},
+	}
+	if matchesQuotaEntity(partial, entity) {
+		t.Errorf("expected %v not to match %v", partial, entity)
+	}
+
+	otherName := []sarama.QuotaEntityComponent{
+		{EntityType: sarama.QuotaEntityUser, MatchType: sarama.QuotaMatchExact, Name: "bob"},
+		{EntityType: sarama.QuotaEntityClientID, MatchType: sarama.QuotaMatchDefault},
+	}
+	if matchesQuotaEntity(otherName, entity) {
+		t.Errorf("expected %v not to match %v", otherName, entity)
+	}
+}
+
+func TestQuotaFilterComponent(t *testing.T) {
+	component, err := QuotaFilter{EntityType: "user", MatchType: "exact", Name: "alice"}.component()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if component.MatchType != sarama.QuotaMatchExact || component.Match != "alice" {
+		t.Errorf("unexpected component %v", component)
+	}
+
+	component, err = QuotaFilter{EntityType: "client-id", MatchType: "default"}.component()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if component.MatchType != sarama.QuotaMatchDefault {
+		t.Errorf("unexpected component %v", component)
+	}
+
+	component, err = QuotaFilter{EntityType: "ip", MatchType: "any"}.component()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if component.MatchType != sarama.QuotaMatchAny {
+		t.Errorf("unexpected component %v", component)
+	}
+
+	if _, err := (QuotaFilter{EntityType: "user", MatchType: "exact"}).component(); err == nil {
+		t.Error("expected an exact match without a name to be rejected")
+	}
+	if _, err := (QuotaFilter{EntityType: "user", MatchType: "prefix"}).component(); err == nil {
+		t.Error("expected an unknown match type to be rejected")
+	}
+}
+
+func TestQuotaFromEntry(t *testing.T) {
+	q := quotaFromEntry(sarama.DescribeClientQuotasEntry{
+		Entity: []sarama.QuotaEntityComponent{
+			{EntityType: sarama.QuotaEntityClientID, MatchType: sarama.QuotaMatchExact, Name: "app"},
+			{EntityType: sarama.QuotaEntityUser, MatchType: sarama.QuotaMatchDefault},
+		},
+		Values: map[string]float64{
+			"producer_byte_rate": 2000,
+			"consumer_byte_rate": 1000,
+		},
+	})
+
+	if id := q.ID(); id != "entity-default|user|app|client-id" {
+		t.Errorf("expected user to sort before client-id, got %s", id)
+	}
+	if len(q.Ops) != 2 || q.Ops[0].Key != "consumer_byte_rate" || q.Ops[1].Value != 2000 {
+		t.Errorf("unexpected ops %v", q.Ops)
+	}
+}
+
+func TestValidateQuotaConfig(t *testing.T) {
+	user := []QuotaEntity{{Type: "user", Name: "alice"}}
+	userClient := []QuotaEntity{{Type: "user", Name: "alice"}, {Type: "client-id", Name: "app"}}
+	ip := []QuotaEntity{{Type: "ip", Name: "203.0.113.1"}}
+
+	tests := []struct {
+		name   string
+		entity []QuotaEntity
+		config map[string]float64
+		errors int
+	}{
+		{"user bandwidth", user, map[string]float64{"producer_byte_rate": 1024, "consumer_byte_rate": 2048}, 0},
+		{"composite request percentage", userClient, map[string]float64{"request_percentage": 12.5, "controller_mutation_rate": 0.5}, 0},
+		{"ip connection rate", ip, map[string]float64{"connection_creation_rate": 10}, 0},
+		{"connection rate on a user", user, map[string]float64{"connection_creation_rate": 10}, 1},
+		{"bandwidth on an ip", ip, map[string]float64{"producer_byte_rate": 1024}, 1},
+		{"unknown key", user, map[string]float64{"producer_bytes_rate": 1024}, 1},
+		{"negative rate", user, map[string]float64{"consumer_byte_rate": -1}, 1},
+		{"fractional bytes", user, map[string]float64{"consumer_byte_rate": 1.5}, 1},
+		{"connection rate out of range", ip, map[string]float64{"connection_creation_rate": 1 << 40}, 1},
+		{"every problem is reported", user, map[string]float64{"consumer_byte_rate": -1, "connection_creation_rate": 1}, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validateQuotaConfig(test.entity, test.config)
+			if len(errs) != test.errors {
+				t.Errorf("expected %d errors, got %v", test.errors, errs)
+			}
+		})
+	}
+}
+
+func TestValidQuotaKeys(t *testing.T) {
+	keys := validQuotaKeys([]QuotaEntity{{Type: "ip"}})
+	if len(keys) != 1 || keys[0] != "connection_creation_rate" {
+		t.Errorf("unexpected keys for ip %v", keys)
+	}
+
+	keys = validQuotaKeys([]QuotaEntity{{Type: "client-id"}})
+	if slices.Contains(keys, "connection_creation_rate") || !slices.Contains(keys, "producer_byte_rate") {
+		t.Errorf("unexpected keys for client-id %v", keys)
+	}
+}
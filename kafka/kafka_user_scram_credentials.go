@@ -2,8 +2,10 @@ package kafka
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/IBM/sarama"
@@ -34,19 +36,46 @@ const (
 	saltSize = 64
 )
 
+// userScramCredentialsAlterer is implemented by sarama's cluster admin
+type userScramCredentialsAlterer interface {
+	AlterUserScramCredentials(upserts []sarama.AlterUserScramCredentialsUpsert, deletes []sarama.AlterUserScramCredentialsDelete) ([]*sarama.AlterUserScramCredentialsResult, error)
+}
+
 func (c *Client) UpsertUserScramCredential(userScramCredential UserScramCredential) error {
-	log.Printf("[INFO] Upserting user scram credential %v", userScramCredential)
-	admin, err := sarama.NewClusterAdminFromClient(c.client)
-	if err != nil {
-		return err
+	return c.AlterUserScramCredentials([]UserScramCredential{userScramCredential}, nil)
+}
+
+// AlterUserScramCredentials upserts and deletes credentials in a single
+// request, so a user can switch mechanisms without losing every credential
+func (c *Client) AlterUserScramCredentials(upserts []UserScramCredential, deletes []UserScramCredential) error {
+	log.Printf("[INFO] Altering user scram credentials: upserting %v, deleting %v", upserts, deletes)
+
+	preparedUpserts := make([]sarama.AlterUserScramCredentialsUpsert, 0, len(upserts))
+	for _, userScramCredential := range upserts {
+		upsert, err := prepareUpsert(userScramCredential)
+		if err != nil {
+			return err
+		}
+		preparedUpserts = append(preparedUpserts, upsert)
+	}
+
+	preparedDeletes := make([]sarama.AlterUserScramCredentialsDelete, 0, len(deletes))
+	for _, userScramCredential := range deletes {
+		preparedDeletes = append(preparedDeletes, prepareDelete(userScramCredential))
 	}
 
-	upsert, err := prepareUpsert(userScramCredential)
+	admin, err := sarama.NewClusterAdminFromClient(c.client)
 	if err != nil {
 		return err
 	}
-
-	results, err := admin.UpsertUserScramCredentials([]sarama.AlterUserScramCredentialsUpsert{upsert})
+	// The ClusterAdmin interface only exposes upserts and deletes as separate
+	// requests, but its implementation can send both at once and retries on
+	// controller errors
+	alterer, ok := admin.(userScramCredentialsAlterer)
+	if !ok {
+		return fmt.Errorf("cluster admin %T cannot upsert and delete user scram credentials in one request", admin)
+	}
+	results, err := alterer.AlterUserScramCredentials(preparedUpserts, preparedDeletes)
 	if err != nil {
 		return err
 	}
@@ -61,6 +90,24 @@ func (c *Client) UpsertUserScramCredential(userScramCredential UserScramCredenti
 }
 
 func (c *Client) DescribeUserScramCredential(username string, mechanism string) (*UserScramCredential, error) {
+	userScramCredentials, err := c.DescribeUserScramCredentials(username)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userScramCredential := range userScramCredentials {
+		if userScramCredential.Mechanism.String() == mechanism {
+			return &userScramCredential, nil
+		}
+	}
+
+	msg := fmt.Sprintf("User scram credential %s with mechanism %s could not be found", username, mechanism)
+	return nil, UserScramCredentialMissingError{msg: msg}
+}
+
+// DescribeUserScramCredentials returns the credential of every mechanism of
+// the user, sorted by mechanism
+func (c *Client) DescribeUserScramCredentials(username string) ([]UserScramCredential, error) {
 	log.Printf("[INFO] Describing user scram credential %s", username)
 	admin, err := sarama.NewClusterAdminFromClient(c.client)
 	if err != nil {
@@ -85,41 +132,101 @@ func (c *Client) DescribeUserScramCredential(username string, mechanism string)
 	if res.ErrorCode != sarama.ErrNoError {
 		return nil, fmt.Errorf("error describing user scram credential %s: %s", username, res.ErrorCode)
 	}
-	for _, info := range res.CredentialInfos {
-		if info.Mechanism.String() == mechanism {
-			r := UserScramCredential{
-				Name:       username,
-				Mechanism:  info.Mechanism,
-				Iterations: info.Iterations,
-			}
-			return &r, nil
-		}
-	}
 
-	msg := fmt.Sprintf("User scram credential %s with mechanism %s could not be found", username, mechanism)
-	return nil, UserScramCredentialMissingError{msg: msg}
+	return userScramCredentialsFromResult(res), nil
 }
 
-func (c *Client) DeleteUserScramCredential(userScramCredential UserScramCredential) error {
-	log.Printf("[INFO] Deleting user scram credential %v", userScramCredential)
+// ListUserScramCredentials returns the credentials of every SCRAM user,
+// sorted by user and mechanism
+func (c *Client) ListUserScramCredentials() ([]UserScramCredential, error) {
+	log.Printf("[INFO] Listing user scram credentials")
 	admin, err := sarama.NewClusterAdminFromClient(c.client)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	delete := prepareDelete(userScramCredential)
-	results, err := admin.DeleteUserScramCredentials([]sarama.AlterUserScramCredentialsDelete{delete})
+	// No users describes every user
+	results, err := admin.DescribeUserScramCredentials(nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	userScramCredentials := []UserScramCredential{}
 	for _, res := range results {
 		if res.ErrorCode != sarama.ErrNoError {
-			return res.ErrorCode
+			return nil, fmt.Errorf("error describing user scram credential %s: %s", res.User, res.ErrorCode)
 		}
+		userScramCredentials = append(userScramCredentials, userScramCredentialsFromResult(res)...)
 	}
+	sort.SliceStable(userScramCredentials, func(i, j int) bool {
+		return userScramCredentials[i].Name < userScramCredentials[j].Name
+	})
 
-	return nil
+	return userScramCredentials, nil
+}
+
+func userScramCredentialsFromResult(res *sarama.DescribeUserScramCredentialsResult) []UserScramCredential {
+	userScramCredentials := make([]UserScramCredential, 0, len(res.CredentialInfos))
+	for _, info := range res.CredentialInfos {
+		userScramCredentials = append(userScramCredentials, UserScramCredential{
+			Name:       res.User,
+			Mechanism:  info.Mechanism,
+			Iterations: info.Iterations,
+		})
+	}
+	sort.Slice(userScramCredentials, func(i, j int) bool {
+		return userScramCredentials[i].Mechanism < userScramCredentials[j].Mechanism
+	})
+	return userScramCredentials
+}
+
+func (c *Client) DeleteUserScramCredential(userScramCredential UserScramCredential) error {
+	return c.AlterUserScramCredentials(nil, []UserScramCredential{userScramCredential})
+}
+
+// VerifyUserScramLogin completes a SCRAM handshake as the user on a separate
+// connection to each address, or to every broker if none are given
+func (c *Client) VerifyUserScramLogin(userScramCredential UserScramCredential, addrs []string) error {
+	log.Printf("[INFO] Verifying login with user scram credential %v", userScramCredential)
+	conf, err := c.config.newKafkaConfig()
+	if err != nil {
+		return err
+	}
+
+	hashGenerator := SHA256
+	if userScramCredential.Mechanism == sarama.SCRAM_MECHANISM_SHA_512 {
+		hashGenerator = SHA512
+	}
+	conf.Net.SASL.Enable = true
+	conf.Net.SASL.Handshake = true
+	conf.Net.SASL.Version = sarama.SASLHandshakeV1
+	conf.Net.SASL.Mechanism = sarama.SASLMechanism(userScramCredential.Mechanism.String())
+	conf.Net.SASL.User = userScramCredential.Name
+	conf.Net.SASL.Password = string(userScramCredential.Password)
+	conf.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &XDGSCRAMClient{HashGeneratorFcn: hashGenerator} }
+	conf.Net.SASL.TokenProvider = nil
+
+	if len(addrs) == 0 {
+		for _, broker := range c.client.Brokers() {
+			addrs = append(addrs, broker.Addr())
+		}
+	}
+
+	errs := []error{}
+	for _, addr := range addrs {
+		broker := sarama.NewBroker(addr)
+		if err := broker.Open(conf); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", addr, err))
+			continue
+		}
+		// Connected waits for the connection, including the SASL handshake
+		if _, err := broker.Connected(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", addr, err))
+		}
+		_ = broker.Close()
+	}
+
+	return errors.Join(errs...)
 }
 
 func prepareUpsert(userScramCredential UserScramCredential) (sarama.AlterUserScramCredentialsUpsert, error) {
@@ -0,0 +1,77 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestVerifyUserScramLogin(t *testing.T) {
+	tests := []struct {
+		name      string
+		handshake *sarama.MockSaslHandshakeResponse
+		auth      *sarama.MockSaslAuthenticateResponse
+	}{
+		{
+			name:      "authentication failed",
+			handshake: sarama.NewMockSaslHandshakeResponse(t).SetEnabledMechanisms([]string{sarama.SASLTypeSCRAMSHA256}),
+			auth:      sarama.NewMockSaslAuthenticateResponse(t).SetError(sarama.ErrSASLAuthenticationFailed),
+		},
+		{
+			name:      "mechanism not enabled",
+			handshake: sarama.NewMockSaslHandshakeResponse(t).SetError(sarama.ErrUnsupportedSASLMechanism).SetEnabledMechanisms([]string{sarama.SASLTypePlaintext}),
+			auth:      sarama.NewMockSaslAuthenticateResponse(t),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := sarama.NewMockBroker(t, 1)
+			defer broker.Close()
+			broker.SetHandlerByMap(map[string]sarama.MockResponse{
+				"ApiVersionsRequest":      sarama.NewMockApiVersionsResponse(t),
+				"SaslHandshakeRequest":    tt.handshake,
+				"SaslAuthenticateRequest": tt.auth,
+			})
+
+			c := &Client{config: &Config{Timeout: 5}}
+			err := c.VerifyUserScramLogin(UserScramCredential{
+				Name:      "alice",
+				Mechanism: sarama.SCRAM_MECHANISM_SHA_256,
+				Password:  []byte("secret"),
+			}, []string{broker.Addr()})
+			if err == nil {
+				t.Fatal("expected the login to fail")
+			}
+		})
+	}
+}
+
+// The combined upsert and delete relies on sarama's cluster admin exposing
+// AlterUserScramCredentials beyond the ClusterAdmin interface
+func TestClusterAdminAltersUserScramCredentials(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_7_0_0
+	client, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := admin.(userScramCredentialsAlterer); !ok {
+		t.Fatalf("expected %T to alter user scram credentials", admin)
+	}
+}
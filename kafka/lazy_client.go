@@ -105,6 +105,14 @@ func (c *LazyClient) CanAlterReplicationFactor() (bool, error) {
 	return c.inner.CanAlterReplicationFactor(), nil
 }
 
+func (c *LazyClient) Capabilities() (Capabilities, error) {
+	err := c.init()
+	if err != nil {
+		return Capabilities{}, err
+	}
+	return c.inner.Capabilities(), nil
+}
+
 func (c *LazyClient) AlterReplicationFactor(t Topic) error {
 	err := c.init()
 	if err != nil {
@@ -129,6 +137,22 @@ func (c *LazyClient) CreateACL(s StringlyTypedACL) error {
 	return c.inner.CreateACL(s)
 }
 
+func (c *LazyClient) CreateACLs(acls []StringlyTypedACL) error {
+	err := c.init()
+	if err != nil {
+		return err
+	}
+	return c.inner.CreateACLs(acls)
+}
+
+func (c *LazyClient) DeleteACLs(acls []StringlyTypedACL) error {
+	err := c.init()
+	if err != nil {
+		return err
+	}
+	return c.inner.DeleteACLs(acls)
+}
+
 func (c *LazyClient) InvalidateACLCache() error {
 	err := c.init()
 	if err != nil {
@@ -146,6 +170,22 @@ func (c *LazyClient) ListACLs() ([]*sarama.ResourceAcls, error) {
 	return c.inner.ListACLs()
 }
 
+func (c *LazyClient) ResourceACLs(name string) ([]*sarama.ResourceAcls, error) {
+	err := c.init()
+	if err != nil {
+		return nil, err
+	}
+	return c.inner.ResourceACLs(name)
+}
+
+func (c *LazyClient) FilterACLs(s StringlyTypedACL) ([]StringlyTypedACL, error) {
+	err := c.init()
+	if err != nil {
+		return nil, err
+	}
+	return c.inner.FilterACLs(s)
+}
+
 func (c *LazyClient) DeleteACL(s StringlyTypedACL) error {
 	err := c.init()
 	if err != nil {
@@ -162,12 +202,20 @@ func (c *LazyClient) AlterQuota(q Quota) error {
 	return c.inner.AlterQuota(q, false)
 }
 
-func (c *LazyClient) DescribeQuota(entityType string, entityName string) (*Quota, error) {
+func (c *LazyClient) DescribeQuota(entity []QuotaEntity) (*Quota, error) {
+	err := c.init()
+	if err != nil {
+		return nil, err
+	}
+	return c.inner.DescribeQuota(entity)
+}
+
+func (c *LazyClient) DescribeQuotas(filters []QuotaFilter) ([]Quota, error) {
 	err := c.init()
 	if err != nil {
 		return nil, err
 	}
-	return c.inner.DescribeQuota(entityType, entityName)
+	return c.inner.DescribeQuotas(filters)
 }
 
 func (c *LazyClient) UpsertUserScramCredential(userScramCredential UserScramCredential) error {
@@ -186,6 +234,38 @@ func (c *LazyClient) DescribeUserScramCredential(username string, mechanism stri
 	return c.inner.DescribeUserScramCredential(username, mechanism)
 }
 
+func (c *LazyClient) AlterUserScramCredentials(upserts []UserScramCredential, deletes []UserScramCredential) error {
+	err := c.init()
+	if err != nil {
+		return err
+	}
+	return c.inner.AlterUserScramCredentials(upserts, deletes)
+}
+
+func (c *LazyClient) DescribeUserScramCredentials(username string) ([]UserScramCredential, error) {
+	err := c.init()
+	if err != nil {
+		return nil, err
+	}
+	return c.inner.DescribeUserScramCredentials(username)
+}
+
+func (c *LazyClient) ListUserScramCredentials() ([]UserScramCredential, error) {
+	err := c.init()
+	if err != nil {
+		return nil, err
+	}
+	return c.inner.ListUserScramCredentials()
+}
+
+func (c *LazyClient) VerifyUserScramLogin(userScramCredential UserScramCredential, addrs []string) error {
+	err := c.init()
+	if err != nil {
+		return err
+	}
+	return c.inner.VerifyUserScramLogin(userScramCredential, addrs)
+}
+
 func (c *LazyClient) DeleteUserScramCredential(userScramCredential UserScramCredential) error {
 	err := c.init()
 	if err != nil {
@@ -193,6 +273,46 @@ func (c *LazyClient) DeleteUserScramCredential(userScramCredential UserScramCred
 	}
 	return c.inner.DeleteUserScramCredential(userScramCredential)
 }
+
+func (c *LazyClient) DescribeBrokerConfig(brokerID string) (map[string]*string, error) {
+	err := c.init()
+	if err != nil {
+		return nil, err
+	}
+	return c.inner.DescribeBrokerConfig(brokerID)
+}
+
+func (c *LazyClient) AlterBrokerConfig(brokerID string, config map[string]*string, deleted []string) error {
+	err := c.init()
+	if err != nil {
+		return err
+	}
+	return c.inner.AlterBrokerConfig(brokerID, config, deleted)
+}
+
+func (c *LazyClient) DescribeBrokerLoggers(brokerID string) (map[string]string, error) {
+	err := c.init()
+	if err != nil {
+		return nil, err
+	}
+	return c.inner.DescribeBrokerLoggers(brokerID)
+}
+
+func (c *LazyClient) AlterBrokerLoggers(brokerID string, levels map[string]string, deleted []string) error {
+	err := c.init()
+	if err != nil {
+		return err
+	}
+	return c.inner.AlterBrokerLoggers(brokerID, levels, deleted)
+}
+
+func (c *LazyClient) DescribeCluster() (*Cluster, error) {
+	err := c.init()
+	if err != nil {
+		return nil, err
+	}
+	return c.inner.DescribeCluster()
+}
 func (c *LazyClient) GetKafkaTopics() ([]Topic, error) {
 	err := c.init()
 	if err != nil {
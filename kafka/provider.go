@@ -189,12 +189,26 @@ func Provider() *schema.Provider {
 		ResourcesMap: map[string]*schema.Resource{
 			"kafka_topic":                 kafkaTopicResource(),
 			"kafka_acl":                   kafkaACLResource(),
+			"kafka_acl_set":               kafkaACLSetResource(),
+			"kafka_acl_role":              kafkaACLRoleResource(),
 			"kafka_quota":                 kafkaQuotaResource(),
 			"kafka_user_scram_credential": kafkaUserScramCredentialResource(),
+			"kafka_service_account":       kafkaServiceAccountResource(),
+			"kafka_broker_config":         kafkaBrokerConfigResource(),
+			"kafka_broker_logger":         kafkaBrokerLoggerResource(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"kafka_topic":  kafkaTopicDataSource(),
-			"kafka_topics": kafkaTopicsDataSource(),
+			"kafka_topic":                  kafkaTopicDataSource(),
+			"kafka_topics":                 kafkaTopicsDataSource(),
+			"kafka_quotas":                 kafkaQuotasDataSource(),
+			"kafka_acls":                   kafkaACLsDataSource(),
+			"kafka_acl_authorization":      kafkaACLAuthorizationDataSource(),
+			"kafka_acl_lint":               kafkaACLLintDataSource(),
+			"kafka_tls_principal":          kafkaTLSPrincipalDataSource(),
+			"kafka_user_scram_credentials": kafkaUserScramCredentialsDataSource(),
+			"kafka_cluster":                kafkaClusterDataSource(),
+			"kafka_brokers":                kafkaBrokersDataSource(),
+			"kafka_capabilities":           kafkaCapabilitiesDataSource(),
 		},
 	}
 }
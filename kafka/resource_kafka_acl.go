@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,51 +19,69 @@ func kafkaACLResource() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: aclCreate,
 		ReadContext:   aclRead,
+		UpdateContext: aclUpdate,
 		DeleteContext: aclDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: importACL,
 		},
+		CustomizeDiff: aclCustomDiff,
 		SchemaVersion: 1,
 		MigrateState:  migrateKafkaAclState,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateACLOperation,
+			validateACLHostMaxAddresses,
+		},
 		Schema: map[string]*schema.Schema{
 			"resource_name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "The name of the resource",
 			},
 			"resource_type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiagFunc(validateACLEnum(aclResourceTypes)),
 			},
 			"resource_pattern_type_filter": {
 				Type:             schema.TypeString,
 				Default:          "Literal",
 				Optional:         true,
-				ForceNew:         true,
 				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{"Literal", "Prefixed"}, false)),
 				Description:      "How to match the resource name. Valid values: Literal (exact match) or Prefixed (match resources with the given prefix).",
 			},
 			"acl_principal": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Required:    true,
+				StateFunc:   func(v interface{}) string { return normalizePrincipal(v.(string)) },
+				Description: "The principal, e.g. User:alice. Distinguished names are written in the form Kafka derives from client certificates before the ACL is created.",
 			},
 			"acl_host": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiagFunc(validateACLHost),
+				Description:      "The host the principal connects from: an IP address, * for any host, or a CIDR range such as 10.0.0.0/28, which is managed as one ACL per address.",
+			},
+			"acl_host_max_addresses": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validateDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The largest number of addresses a CIDR acl_host may expand to. Defaults to 256.",
+			},
+			"acl_host_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The addresses acl_host expands to that have an ACL in Kafka. Addresses removed outside Terraform appear as a change to this list.",
 			},
 			"acl_operation": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiagFunc(validateACLEnum(aclOperations)),
 			},
 			"acl_permission_type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiagFunc(validateACLEnum(aclPermissionTypes)),
 			},
 		},
 	}
@@ -71,8 +91,13 @@ func aclCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	c := meta.(*LazyClient)
 	a := aclInfo(d)
 
+	acls, err := expandACLHosts(a, aclHostMaxAddresses(d.Get("acl_host_max_addresses")))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	log.Printf("[INFO] Creating ACL %s", a)
-	err := c.CreateACL(a)
+	err = createQueuedACLs(c, acls)
 
 	if err != nil {
 		log.Println("[ERROR] Failed to create ACL")
@@ -84,12 +109,75 @@ func aclCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	// Wait for ACL to be visible in Kafka before returning
 	// This handles eventual consistency and ensures the ACL is actually created
 	log.Printf("[INFO] Waiting for ACL %s to be visible in Kafka", a)
-	err = waitForACLToBeVisible(ctx, c, a)
+	err = waitForACLsToBeVisible(ctx, c, acls)
 	if err != nil {
 		log.Printf("[ERROR] ACL created but not visible: %v", err)
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("acl_host_addresses", aclHosts(acls)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// aclUpdate replaces the ACL without a window in which neither binding exists:
+// the new ACL is created and confirmed visible before the old one is deleted
+func aclUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	oldACL := aclInfoChange(d, false)
+	newACL := aclInfoChange(d, true)
+
+	// Keep the old ACL in state until it is gone, so a failed update is retried
+	// from the start rather than leaving the old binding behind
+	d.Partial(true)
+
+	// The old ACLs are the addresses last read from the broker, so addresses
+	// deleted outside Terraform are recreated
+	oldAddresses, _ := d.GetChange("acl_host_addresses")
+	oldMaxAddresses, newMaxAddresses := d.GetChange("acl_host_max_addresses")
+	oldACLs := aclWithHosts(oldACL, stringList(oldAddresses))
+	if len(oldACLs) == 0 {
+		var err error
+		if oldACLs, err = expandACLHosts(oldACL, aclHostMaxAddresses(oldMaxAddresses)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	newACLs, err := expandACLHosts(newACL, aclHostMaxAddresses(newMaxAddresses))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Addresses in both the old and new range are left alone
+	created := aclsDifference(newACLs, oldACLs)
+	deleted := aclsDifference(oldACLs, newACLs)
+
+	log.Printf("[INFO] Replacing ACL %s with %s", oldACL, newACL)
+	if len(created) > 0 {
+		if err := createQueuedACLs(c, created); err != nil {
+			log.Println("[ERROR] Failed to create replacement ACL")
+			return diag.FromErr(err)
+		}
+		if err := waitForACLsToBeVisible(ctx, c, created); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if len(deleted) > 0 {
+		if err := deleteQueuedACLs(c, deleted); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitForACLsToBeDeleted(ctx, c, deleted); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.Partial(false)
+	d.SetId(newACL.String())
+	if err := d.Set("acl_host_addresses", aclHosts(newACLs)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -98,7 +186,12 @@ func aclDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	a := aclInfo(d)
 	log.Printf("[INFO] Deleting ACL %s", a)
 
-	err := c.DeleteACL(a)
+	acls, err := expandACLHosts(a, aclHostMaxAddresses(d.Get("acl_host_max_addresses")))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = deleteQueuedACLs(c, acls)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -106,7 +199,7 @@ func aclDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	// Wait for ACL to be removed from Kafka before returning
 	// This handles eventual consistency and ensures the ACL is actually deleted
 	log.Printf("[INFO] Waiting for ACL %s to be removed from Kafka", a)
-	err = waitForACLToBeDeleted(ctx, c, a)
+	err = waitForACLsToBeDeleted(ctx, c, acls)
 	if err != nil {
 		log.Printf("[ERROR] ACL deletion requested but still visible: %v", err)
 		return diag.FromErr(err)
@@ -121,16 +214,18 @@ func aclRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag
 	a := aclInfo(d)
 	log.Printf("[INFO] Reading ACL %s", a)
 
-	currentACLs, err := c.ListACLs()
+	acls, err := expandACLHosts(a, aclHostMaxAddresses(d.Get("acl_host_max_addresses")))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	currentACLs, err := c.ResourceACLs(a.Name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	found := map[string]bool{}
 	for _, foundACLs := range currentACLs {
-		// find only ACLs where ResourceName matches
-		if foundACLs.ResourceName != a.Name {
-			continue
-		}
 		if len(foundACLs.Acls) < 1 {
 			continue
 		}
@@ -150,18 +245,29 @@ func aclRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag
 					PatternTypeFilter: foundACLs.ResourcePatternType.String(),
 				},
 			}
+			found[aclID.String()] = true
+		}
+	}
 
-			// Found the ACL, so no need to remove it from state
-			if a.String() == aclID.String() {
-				return nil
-			}
+	// Record the addresses of a CIDR range that still exist, so missing ones
+	// show up as a change to acl_host_addresses
+	addresses := []string{}
+	for _, expected := range acls {
+		if found[expected.String()] {
+			addresses = append(addresses, expected.ACL.Host)
 		}
 	}
 
-	// If we get here, the ACL was not found
-	log.Printf("[INFO] Did not find ACL %s", a.String())
-	d.SetId("")
+	if len(addresses) == 0 {
+		log.Printf("[INFO] Did not find ACL %s", a.String())
+		d.SetId("")
+		return nil
+	}
 
+	// Found the ACL, so no need to remove it from state
+	if err := d.Set("acl_host_addresses", addresses); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -186,6 +292,109 @@ func importACL(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*s
 	return []*schema.ResourceData{d}, nil
 }
 
+// aclCustomDiff plans the addresses acl_host expands to, so addresses missing
+// from Kafka produce a diff even though acl_host is unchanged
+func aclCustomDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if !diff.NewValueKnown("acl_host") || !diff.NewValueKnown("acl_host_max_addresses") {
+		return diff.SetNewComputed("acl_host_addresses")
+	}
+
+	// acl_host may have been unknown when the config was validated, so the
+	// cap is enforced again here
+	acls, err := expandACLHosts(StringlyTypedACL{ACL: ACL{Host: diff.Get("acl_host").(string)}}, aclHostMaxAddresses(diff.Get("acl_host_max_addresses")))
+	if err != nil {
+		return err
+	}
+
+	expected := aclHosts(acls)
+	old, _ := diff.GetChange("acl_host_addresses")
+	if slices.Equal(stringList(old), expected) {
+		return nil
+	}
+	return diff.SetNew("acl_host_addresses", expected)
+}
+
+// validateACLHost rejects malformed CIDR ranges. Other hosts are passed to
+// the broker as they are.
+func validateACLHost(v interface{}, k string) ([]string, []error) {
+	host, ok := v.(string)
+	if !ok || !isACLHostRange(host) {
+		return nil, nil
+	}
+	if _, err := parseACLHostRange(host); err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// validateACLHostMaxAddresses rejects CIDR ranges with more addresses than
+// acl_host_max_addresses at plan time
+func validateACLHostMaxAddresses(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	host, ok := rawStringAttr(req.RawConfig, "acl_host")
+	if !ok {
+		return
+	}
+
+	maxAddresses := defaultACLHostMaxAddresses
+	if v := req.RawConfig.GetAttr("acl_host_max_addresses"); !v.IsNull() {
+		if !v.IsKnown() {
+			return
+		}
+		n, _ := v.AsBigFloat().Int64()
+		maxAddresses = int(n)
+	}
+
+	if err := validateACLHostRange(host, maxAddresses); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "CIDR range too large",
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("acl_host"),
+		})
+	}
+}
+
+// validateACLOperation warns about operations Kafka never authorizes on the
+// configured resource type, e.g. Read on a Cluster
+func validateACLOperation(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	resp.Diagnostics = append(resp.Diagnostics, aclOperationDiagnostics(req.RawConfig, cty.Path{})...)
+}
+
+// aclOperationDiagnostics checks the resource_type and acl_operation
+// attributes of a raw config object, skipping unknown values
+func aclOperationDiagnostics(v cty.Value, path cty.Path) diag.Diagnostics {
+	resourceType, ok := rawStringAttr(v, "resource_type")
+	if !ok {
+		return nil
+	}
+	operation, ok := rawStringAttr(v, "acl_operation")
+	if !ok {
+		return nil
+	}
+
+	warning := aclOperationWarning(resourceType, operation)
+	if warning == "" {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "Invalid ACL operation for resource type",
+		Detail:        warning,
+		AttributePath: path.GetAttr("acl_operation"),
+	}}
+}
+
+func rawStringAttr(v cty.Value, name string) (string, bool) {
+	if v.IsNull() || !v.IsKnown() || !v.Type().IsObjectType() || !v.Type().HasAttribute(name) {
+		return "", false
+	}
+	attr := v.GetAttr(name)
+	if attr.IsNull() || !attr.IsKnown() || attr.Type() != cty.String {
+		return "", false
+	}
+	return attr.AsString(), true
+}
+
 type errSetter struct {
 	err error
 	d   *schema.ResourceData
@@ -216,6 +425,31 @@ func aclInfo(d *schema.ResourceData) StringlyTypedACL {
 	return s
 }
 
+// aclInfoChange returns the ACL before or after a pending change
+func aclInfoChange(d *schema.ResourceData, after bool) StringlyTypedACL {
+	get := func(key string) string {
+		o, n := d.GetChange(key)
+		if after {
+			return n.(string)
+		}
+		return o.(string)
+	}
+
+	return StringlyTypedACL{
+		ACL: ACL{
+			Principal:      get("acl_principal"),
+			Host:           get("acl_host"),
+			Operation:      get("acl_operation"),
+			PermissionType: get("acl_permission_type"),
+		},
+		Resource: Resource{
+			Type:              get("resource_type"),
+			Name:              get("resource_name"),
+			PatternTypeFilter: get("resource_pattern_type_filter"),
+		},
+	}
+}
+
 // waitForACLToBeVisible waits for an ACL to be visible in Kafka after creation
 // This handles eventual consistency issues with Kafka ACL propagation
 func waitForACLToBeVisible(ctx context.Context, c *LazyClient, expectedACL StringlyTypedACL) error {
@@ -230,45 +464,13 @@ func waitForACLToBeVisible(ctx context.Context, c *LazyClient, expectedACL Strin
 		default:
 		}
 
-		// Invalidate cache to ensure we get fresh data
-		err := c.InvalidateACLCache()
-		if err != nil {
-			return fmt.Errorf("failed to invalidate ACL cache: %w", err)
-		}
-
-		// List all ACLs
-		acls, err := c.ListACLs()
+		found, err := aclExists(c, expectedACL)
 		if err != nil {
-			return fmt.Errorf("failed to list ACLs: %w", err)
+			return err
 		}
-
-		// Check if our ACL exists
-		for _, foundACLs := range acls {
-			if foundACLs.ResourceName != expectedACL.Name {
-				continue
-			}
-
-			for _, acl := range foundACLs.Acls {
-				foundACL := StringlyTypedACL{
-					ACL: ACL{
-						Principal:      acl.Principal,
-						Host:           acl.Host,
-						Operation:      ACLOperationToString(acl.Operation),
-						PermissionType: ACLPermissionTypeToString(acl.PermissionType),
-					},
-					Resource: Resource{
-						Type:              ACLResourceToString(foundACLs.ResourceType),
-						Name:              foundACLs.ResourceName,
-						PatternTypeFilter: foundACLs.ResourcePatternType.String(),
-					},
-				}
-
-				// Check for exact match
-				if expectedACL.String() == foundACL.String() {
-					log.Printf("[INFO] ACL %s is now visible in Kafka (attempt %d)", expectedACL, i+1)
-					return nil
-				}
-			}
+		if found {
+			log.Printf("[INFO] ACL %s is now visible in Kafka (attempt %d)", expectedACL, i+1)
+			return nil
 		}
 
 		// If not found and not the last attempt, wait before retrying
@@ -295,50 +497,9 @@ func waitForACLToBeDeleted(ctx context.Context, c *LazyClient, deletedACL String
 		default:
 		}
 
-		// Invalidate cache to ensure we get fresh data
-		err := c.InvalidateACLCache()
+		found, err := aclExists(c, deletedACL)
 		if err != nil {
-			return fmt.Errorf("failed to invalidate ACL cache: %w", err)
-		}
-
-		// List all ACLs
-		acls, err := c.ListACLs()
-		if err != nil {
-			return fmt.Errorf("failed to list ACLs: %w", err)
-		}
-
-		// Check if our ACL still exists
-		found := false
-		for _, foundACLs := range acls {
-			if foundACLs.ResourceName != deletedACL.Name {
-				continue
-			}
-
-			for _, acl := range foundACLs.Acls {
-				foundACL := StringlyTypedACL{
-					ACL: ACL{
-						Principal:      acl.Principal,
-						Host:           acl.Host,
-						Operation:      ACLOperationToString(acl.Operation),
-						PermissionType: ACLPermissionTypeToString(acl.PermissionType),
-					},
-					Resource: Resource{
-						Type:              ACLResourceToString(foundACLs.ResourceType),
-						Name:              foundACLs.ResourceName,
-						PatternTypeFilter: foundACLs.ResourcePatternType.String(),
-					},
-				}
-
-				// Check for exact match
-				if deletedACL.String() == foundACL.String() {
-					found = true
-					break
-				}
-			}
-
-			if found {
-				break
-			}
+			return err
 		}
 
 		// If not found, the ACL has been successfully deleted
@@ -356,3 +517,82 @@ func waitForACLToBeDeleted(ctx context.Context, c *LazyClient, deletedACL String
 
 	return fmt.Errorf("ACL %s was still visible in Kafka after %d attempts over %v", deletedACL, maxRetries, time.Duration(maxRetries)*retryInterval)
 }
+
+// waitForACLsToBeVisible waits for every ACL a CIDR range expanded to
+func waitForACLsToBeVisible(ctx context.Context, c *LazyClient, acls []StringlyTypedACL) error {
+	if len(acls) == 1 {
+		return waitForACLToBeVisible(ctx, c, acls[0])
+	}
+	return waitForACLSet(ctx, c, acls[0].ACL.Principal, acls, nil)
+}
+
+// waitForACLsToBeDeleted waits for every ACL a CIDR range expanded to to be
+// removed
+func waitForACLsToBeDeleted(ctx context.Context, c *LazyClient, acls []StringlyTypedACL) error {
+	if len(acls) == 1 {
+		return waitForACLToBeDeleted(ctx, c, acls[0])
+	}
+	return waitForACLSet(ctx, c, acls[0].ACL.Principal, nil, acls)
+}
+
+// aclWithHosts returns a copy of the ACL for each host
+func aclWithHosts(a StringlyTypedACL, hosts []string) []StringlyTypedACL {
+	acls := make([]StringlyTypedACL, 0, len(hosts))
+	for _, host := range hosts {
+		withHost := a
+		withHost.ACL.Host = host
+		acls = append(acls, withHost)
+	}
+	return acls
+}
+
+func aclHosts(acls []StringlyTypedACL) []string {
+	hosts := make([]string, 0, len(acls))
+	for _, a := range acls {
+		hosts = append(hosts, a.ACL.Host)
+	}
+	return hosts
+}
+
+// stringList converts a TypeList of strings read from state or a diff
+func stringList(v interface{}) []string {
+	list, _ := v.([]interface{})
+	result := make([]string, 0, len(list))
+	for _, vv := range list {
+		if s, ok := vv.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// aclsDifference returns the ACLs in acls that are not in other
+func aclsDifference(acls []StringlyTypedACL, other []StringlyTypedACL) []StringlyTypedACL {
+	exclude := make(map[string]bool, len(other))
+	for _, a := range other {
+		exclude[a.String()] = true
+	}
+
+	difference := []StringlyTypedACL{}
+	for _, a := range acls {
+		if !exclude[a.String()] {
+			difference = append(difference, a)
+		}
+	}
+	return difference
+}
+
+// aclExists asks the broker for exactly this ACL, bypassing the cache
+func aclExists(c *LazyClient, a StringlyTypedACL) (bool, error) {
+	acls, err := c.FilterACLs(a)
+	if err != nil {
+		return false, fmt.Errorf("failed to describe ACL %s: %w", a, err)
+	}
+
+	for _, found := range acls {
+		if found.String() == a.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
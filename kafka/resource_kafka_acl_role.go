@@ -0,0 +1,441 @@
+package kafka
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	aclRoleProducer = "producer"
+	aclRoleConsumer = "consumer"
+	aclRoleAdmin    = "admin"
+
+	// clusterResourceName is the only name a Cluster ACL can have
+	clusterResourceName = "kafka-cluster"
+
+	// aclRoleMarkerPrefix starts the name of the marker ACL of every role
+	aclRoleMarkerPrefix = "terraform-kafka-acl-role:"
+)
+
+// ACLRole is a high-level role that expands into the low-level ACLs that
+// kafka-acls.sh --producer/--consumer would create
+type ACLRole struct {
+	Role               string `json:"role"`
+	Principal          string `json:"-"`
+	Host               string `json:"-"`
+	Topic              string `json:"topic"`
+	TopicPatternType   string `json:"topic_pattern_type"`
+	Group              string `json:"group,omitempty"`
+	GroupPatternType   string `json:"group_pattern_type,omitempty"`
+	TransactionalID    string `json:"transactional_id,omitempty"`
+	IdempotentProducer bool   `json:"idempotent_producer,omitempty"`
+}
+
+func (r ACLRole) ID() string {
+	return strings.Join([]string{
+		r.Principal,
+		r.Host,
+		r.Role,
+		r.Topic,
+		r.TopicPatternType,
+		r.Group,
+		r.GroupPatternType,
+		r.TransactionalID,
+		strconv.FormatBool(r.IdempotentProducer),
+	}, "|")
+}
+
+// Expand returns the ACLs that make up the role
+func (r ACLRole) Expand() ([]StringlyTypedACL, error) {
+	acl := func(resourceType, name, patternType, operation string) StringlyTypedACL {
+		return StringlyTypedACL{
+			ACL: ACL{
+				Principal:      r.Principal,
+				Host:           r.Host,
+				Operation:      operation,
+				PermissionType: "Allow",
+			},
+			Resource: Resource{
+				Type:              resourceType,
+				Name:              name,
+				PatternTypeFilter: patternType,
+			},
+		}
+	}
+
+	acls := []StringlyTypedACL{}
+	switch r.Role {
+	case aclRoleProducer:
+		if r.Group != "" {
+			return nil, fmt.Errorf("group can only be set for the %s and %s roles", aclRoleConsumer, aclRoleAdmin)
+		}
+		for _, op := range []string{"Write", "Describe", "Create"} {
+			acls = append(acls, acl("Topic", r.Topic, r.TopicPatternType, op))
+		}
+		if r.IdempotentProducer {
+			acls = append(acls, acl("Cluster", clusterResourceName, "Literal", "IdempotentWrite"))
+		}
+		if r.TransactionalID != "" {
+			for _, op := range []string{"Write", "Describe"} {
+				acls = append(acls, acl("TransactionalID", r.TransactionalID, "Literal", op))
+			}
+		}
+	case aclRoleConsumer:
+		if r.Group == "" {
+			return nil, fmt.Errorf("group is required for the %s role", aclRoleConsumer)
+		}
+		if r.IdempotentProducer || r.TransactionalID != "" {
+			return nil, fmt.Errorf("idempotent_producer and transactional_id can only be set for the %s role", aclRoleProducer)
+		}
+		for _, op := range []string{"Read", "Describe"} {
+			acls = append(acls, acl("Topic", r.Topic, r.TopicPatternType, op))
+		}
+		acls = append(acls, acl("Group", r.Group, r.GroupPatternType, "Read"))
+	case aclRoleAdmin:
+		if r.IdempotentProducer || r.TransactionalID != "" {
+			return nil, fmt.Errorf("idempotent_producer and transactional_id can only be set for the %s role", aclRoleProducer)
+		}
+		acls = append(acls, acl("Topic", r.Topic, r.TopicPatternType, "All"))
+		if r.Group != "" {
+			acls = append(acls, acl("Group", r.Group, r.GroupPatternType, "All"))
+		}
+	default:
+		return nil, fmt.Errorf("unknown role '%s': can only be \"%s\", \"%s\" or \"%s\"", r.Role, aclRoleProducer, aclRoleConsumer, aclRoleAdmin)
+	}
+
+	return acls, nil
+}
+
+// ACLs returns the ACLs of the role followed by its marker
+func (r ACLRole) ACLs() ([]StringlyTypedACL, error) {
+	acls, err := r.Expand()
+	if err != nil {
+		return nil, err
+	}
+	marker, err := r.Marker()
+	if err != nil {
+		return nil, err
+	}
+	return append(acls, marker), nil
+}
+
+// Marker returns the ACL recording that the role exists, so roles of the same
+// principal and host that expand to the same ACLs can tell which ACLs are
+// still needed. It allows describing a delegation token whose name holds the
+// role, which no real token can have.
+func (r ACLRole) Marker() (StringlyTypedACL, error) {
+	encoded, err := json.Marshal(r)
+	if err != nil {
+		return StringlyTypedACL{}, err
+	}
+	return StringlyTypedACL{
+		ACL: ACL{
+			Principal:      r.Principal,
+			Host:           r.Host,
+			Operation:      "Describe",
+			PermissionType: "Allow",
+		},
+		Resource: Resource{
+			Type:              "DelegationToken",
+			Name:              aclRoleMarkerPrefix + base64.RawURLEncoding.EncodeToString(encoded),
+			PatternTypeFilter: "Literal",
+		},
+	}, nil
+}
+
+// aclRoleFromMarker returns the role a marker ACL records, and false for any
+// other ACL
+func aclRoleFromMarker(a StringlyTypedACL) (ACLRole, bool) {
+	encoded, ok := strings.CutPrefix(a.Resource.Name, aclRoleMarkerPrefix)
+	if !ok || a.Resource.Type != "DelegationToken" || a.Resource.PatternTypeFilter != "Literal" || a.ACL.Operation != "Describe" || a.ACL.PermissionType != "Allow" {
+		return ACLRole{}, false
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ACLRole{}, false
+	}
+	var role ACLRole
+	if err := json.Unmarshal(decoded, &role); err != nil {
+		return ACLRole{}, false
+	}
+	role.Principal = a.ACL.Principal
+	role.Host = a.ACL.Host
+	return role, true
+}
+
+// Unshared splits the ACLs of the role into those it can delete and those
+// another role still needs. The other roles of the same principal and host
+// are found from their markers in current and expanded, so two consumers of
+// one topic keep the topic ACLs they both expand to until both are gone.
+func (r ACLRole) Unshared(acls []StringlyTypedACL, current []StringlyTypedACL) ([]StringlyTypedACL, []StringlyTypedACL) {
+	own := r.ID()
+	needed := map[string]bool{}
+	for _, a := range current {
+		other, ok := aclRoleFromMarker(a)
+		if !ok || other.Principal != r.Principal || other.Host != r.Host || other.ID() == own {
+			continue
+		}
+		expanded, err := other.Expand()
+		if err != nil {
+			log.Printf("[WARN] Ignoring marker of invalid ACL role %s: %v", other.ID(), err)
+			continue
+		}
+		for _, e := range expanded {
+			needed[e.String()] = true
+		}
+	}
+
+	unshared := []StringlyTypedACL{}
+	shared := []StringlyTypedACL{}
+	for _, a := range acls {
+		if needed[a.String()] {
+			shared = append(shared, a)
+		} else {
+			unshared = append(unshared, a)
+		}
+	}
+	return unshared, shared
+}
+
+func kafkaACLRoleResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: aclRoleCreate,
+		ReadContext:   aclRoleRead,
+		DeleteContext: aclRoleDelete,
+		CustomizeDiff: aclRoleCustomDiff,
+		Description:   "Grants a principal a producer, consumer or admin role, expanded into the ACLs the role needs.",
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{aclRoleProducer, aclRoleConsumer, aclRoleAdmin}, false)),
+				Description:      "The role to grant (producer, consumer, admin)",
+			},
+			"acl_principal": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The principal to grant the role to, e.g. User:Alice",
+			},
+			"acl_host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "*",
+				Description: "The host the principal is allowed from",
+			},
+			"topic": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The topic the role applies to",
+			},
+			"topic_pattern_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "Literal",
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{"Literal", "Prefixed"}, false)),
+				Description:      "How to match the topic name (Literal, Prefixed)",
+			},
+			"group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The consumer group the role applies to. Required for the consumer role",
+			},
+			"group_pattern_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "Literal",
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{"Literal", "Prefixed"}, false)),
+				Description:      "How to match the group name (Literal, Prefixed)",
+			},
+			"transactional_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Grant a producer Write and Describe on this transactional id",
+			},
+			"idempotent_producer": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Grant a producer IdempotentWrite on the cluster",
+			},
+			"acls": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The ACLs the role expands to and its marker ACL, in the kafka_acl import ID format",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func aclRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	role := aclRoleInfo(d)
+
+	acls, err := role.ACLs()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Creating %s role for %s", role.Role, role.Principal)
+	if err := createQueuedACLs(c, acls); err != nil {
+		log.Println("[ERROR] Failed to create ACL role")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(role.ID())
+
+	if err := waitForACLSet(ctx, c, role.Principal, acls, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return aclRoleRead(ctx, d, meta)
+}
+
+func aclRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	role := aclRoleInfo(d)
+	log.Printf("[INFO] Reading %s role for %s", role.Role, role.Principal)
+
+	acls, err := role.ACLs()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	currentACLs, err := c.ListACLs()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A role with any of its ACLs missing is recreated as a whole, as are roles
+	// created before roles had a marker
+	if missing := aclSetPending(flattenResourceACLs(currentACLs), acls, nil); len(missing) > 0 {
+		log.Printf("[INFO] ACL role %s is missing %v", d.Id(), missing)
+		d.SetId("")
+		return nil
+	}
+
+	ids := make([]string, 0, len(acls))
+	for _, a := range acls {
+		ids = append(ids, a.String())
+	}
+
+	errSet := errSetter{d: d}
+	errSet.Set("acls", ids)
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	return nil
+}
+
+func aclRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	role := aclRoleInfo(d)
+
+	acls, err := role.ACLs()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := c.FilterACLs(StringlyTypedACL{ACL: ACL{Principal: role.Principal}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	acls, shared := role.Unshared(acls, current)
+	if len(shared) > 0 {
+		log.Printf("[INFO] Keeping ACLs of the %s role for %s still needed by another role: %v", role.Role, role.Principal, shared)
+	}
+	if len(acls) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting %s role for %s", role.Role, role.Principal)
+	if err := deleteQueuedACLs(c, acls); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForACLSet(ctx, c, role.Principal, nil, acls); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func aclRoleCustomDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	for _, key := range []string{"role", "group", "transactional_id", "idempotent_producer"} {
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	_, err := ACLRole{
+		Role:               diff.Get("role").(string),
+		Group:              diff.Get("group").(string),
+		TransactionalID:    diff.Get("transactional_id").(string),
+		IdempotentProducer: diff.Get("idempotent_producer").(bool),
+	}.Expand()
+	return err
+}
+
+// createQueuedACLs enqueues every ACL at once so the creation queue submits
+// them in a single batch
+func createQueuedACLs(c *LazyClient, acls []StringlyTypedACL) error {
+	return forEachACL(acls, c.CreateACL)
+}
+
+// deleteQueuedACLs enqueues every ACL at once so the deletion queue submits
+// them in a single batch
+func deleteQueuedACLs(c *LazyClient, acls []StringlyTypedACL) error {
+	return forEachACL(acls, c.DeleteACL)
+}
+
+func forEachACL(acls []StringlyTypedACL, f func(StringlyTypedACL) error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(acls))
+	for i, a := range acls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f(a); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", a, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func aclRoleInfo(d *schema.ResourceData) ACLRole {
+	return ACLRole{
+		Role:               d.Get("role").(string),
+		Principal:          d.Get("acl_principal").(string),
+		Host:               d.Get("acl_host").(string),
+		Topic:              d.Get("topic").(string),
+		TopicPatternType:   d.Get("topic_pattern_type").(string),
+		Group:              d.Get("group").(string),
+		GroupPatternType:   d.Get("group_pattern_type").(string),
+		TransactionalID:    d.Get("transactional_id").(string),
+		IdempotentProducer: d.Get("idempotent_producer").(bool),
+	}
+}
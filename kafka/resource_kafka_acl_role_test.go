@@ -0,0 +1,309 @@
+package kafka
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAcc_ACLRoleProducerAndConsumer(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	producer := fmt.Sprintf("User:producer-%s", u)
+	consumer := fmt.Sprintf("User:consumer-%s", u)
+	topicName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckACLSetDestroy(producer); err != nil {
+				return err
+			}
+			return testAccCheckACLSetDestroy(consumer)
+		},
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceACLRole_config, producer, consumer, topicName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_acl_role.producer", "acls.#", "7"),
+					r.TestCheckResourceAttr("kafka_acl_role.consumer", "acls.#", "4"),
+					testAccCheckACLSetCount(producer, 7),
+					testAccCheckACLSetCount(consumer, 4),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_ACLRoleInvalid(t *testing.T) {
+	t.Parallel()
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config:      cfg(t, bs, testResourceACLRole_missingGroupConfig),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("group is required for the consumer role"),
+			},
+		},
+	})
+}
+
+func TestACLRoleExpand(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     ACLRole
+		expected []string
+		err      bool
+	}{
+		{
+			name: "producer",
+			role: ACLRole{Role: aclRoleProducer, Principal: "User:Alice", Host: "*", Topic: "payments", TopicPatternType: "Literal"},
+			expected: []string{
+				"User:Alice|*|Write|Allow|Topic|payments|Literal",
+				"User:Alice|*|Describe|Allow|Topic|payments|Literal",
+				"User:Alice|*|Create|Allow|Topic|payments|Literal",
+			},
+		},
+		{
+			name: "transactional idempotent producer",
+			role: ACLRole{Role: aclRoleProducer, Principal: "User:Alice", Host: "*", Topic: "payments", TopicPatternType: "Prefixed", TransactionalID: "tx", IdempotentProducer: true},
+			expected: []string{
+				"User:Alice|*|Write|Allow|Topic|payments|Prefixed",
+				"User:Alice|*|Describe|Allow|Topic|payments|Prefixed",
+				"User:Alice|*|Create|Allow|Topic|payments|Prefixed",
+				"User:Alice|*|IdempotentWrite|Allow|Cluster|kafka-cluster|Literal",
+				"User:Alice|*|Write|Allow|TransactionalID|tx|Literal",
+				"User:Alice|*|Describe|Allow|TransactionalID|tx|Literal",
+			},
+		},
+		{
+			name: "consumer",
+			role: ACLRole{Role: aclRoleConsumer, Principal: "User:Bob", Host: "*", Topic: "payments", TopicPatternType: "Literal", Group: "billing", GroupPatternType: "Prefixed"},
+			expected: []string{
+				"User:Bob|*|Read|Allow|Topic|payments|Literal",
+				"User:Bob|*|Describe|Allow|Topic|payments|Literal",
+				"User:Bob|*|Read|Allow|Group|billing|Prefixed",
+			},
+		},
+		{
+			name: "admin",
+			role: ACLRole{Role: aclRoleAdmin, Principal: "User:Carol", Host: "*", Topic: "payments", TopicPatternType: "Literal", Group: "billing", GroupPatternType: "Literal"},
+			expected: []string{
+				"User:Carol|*|All|Allow|Topic|payments|Literal",
+				"User:Carol|*|All|Allow|Group|billing|Literal",
+			},
+		},
+		{
+			name: "consumer without group",
+			role: ACLRole{Role: aclRoleConsumer, Topic: "payments"},
+			err:  true,
+		},
+		{
+			name: "producer with group",
+			role: ACLRole{Role: aclRoleProducer, Topic: "payments", Group: "billing"},
+			err:  true,
+		},
+		{
+			name: "consumer with transactional id",
+			role: ACLRole{Role: aclRoleConsumer, Topic: "payments", Group: "billing", TransactionalID: "tx"},
+			err:  true,
+		},
+		{
+			name: "unknown role",
+			role: ACLRole{Role: "superuser", Topic: "payments"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acls, err := tt.role.Expand()
+			if tt.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", acls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(acls) != len(tt.expected) {
+				t.Fatalf("expected %d ACLs, got %d: %v", len(tt.expected), len(acls), acls)
+			}
+			for i, a := range acls {
+				if a.String() != tt.expected[i] {
+					t.Errorf("expected ACL %d to be %s, got %s", i, tt.expected[i], a.String())
+				}
+			}
+		})
+	}
+}
+
+const testResourceACLRole_config = `
+resource "kafka_acl_role" "producer" {
+  role                = "producer"
+  acl_principal       = "%[1]s"
+  topic               = "%[3]s"
+  transactional_id    = "%[3]s"
+  idempotent_producer = true
+}
+
+resource "kafka_acl_role" "consumer" {
+  role          = "consumer"
+  acl_principal = "%[2]s"
+  topic         = "%[3]s"
+  group         = "%[3]s"
+}
+`
+
+const testResourceACLRole_missingGroupConfig = `
+resource "kafka_acl_role" "consumer" {
+  role          = "consumer"
+  acl_principal = "User:Alice"
+  topic         = "payments"
+}
+`
+
+func TestACLRoleUnshared(t *testing.T) {
+	role := func(kind, topic, group, transactionalID string, idempotent bool) ACLRole {
+		return ACLRole{Role: kind, Principal: "User:Alice", Host: "*", Topic: topic, TopicPatternType: "Literal", Group: group, GroupPatternType: "Literal", TransactionalID: transactionalID, IdempotentProducer: idempotent}
+	}
+	producer := role(aclRoleProducer, "payments", "", "", true)
+	otherProducer := role(aclRoleProducer, "payments", "", "payments-tx", false)
+	refundsProducer := role(aclRoleProducer, "refunds", "", "", true)
+	consumer := role(aclRoleConsumer, "payments", "billing", "", false)
+	otherConsumer := role(aclRoleConsumer, "payments", "audit", "", false)
+	otherHost := consumer
+	otherHost.Host = "10.0.0.1"
+
+	// Every role exists in Kafka with its marker
+	current := func(roles ...ACLRole) []StringlyTypedACL {
+		acls := []StringlyTypedACL{}
+		for _, r := range roles {
+			expanded, err := r.ACLs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			acls = append(acls, expanded...)
+		}
+		return acls
+	}
+	strs := func(acls []StringlyTypedACL) []string {
+		s := []string{}
+		for _, a := range acls {
+			s = append(s, a.String())
+		}
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		role     ACLRole
+		current  []StringlyTypedACL
+		expected []string
+	}{
+		{
+			name:    "alone",
+			role:    producer,
+			current: current(producer),
+		},
+		{
+			name:     "producer next to a consumer keeps Describe",
+			role:     producer,
+			current:  current(producer, consumer),
+			expected: []string{"User:Alice|*|Describe|Allow|Topic|payments|Literal"},
+		},
+		{
+			name:    "two consumers of one topic keep the topic ACLs",
+			role:    consumer,
+			current: current(consumer, otherConsumer),
+			expected: []string{
+				"User:Alice|*|Read|Allow|Topic|payments|Literal",
+				"User:Alice|*|Describe|Allow|Topic|payments|Literal",
+			},
+		},
+		{
+			name:    "two producers of one topic keep the topic ACLs",
+			role:    producer,
+			current: current(producer, otherProducer),
+			expected: []string{
+				"User:Alice|*|Write|Allow|Topic|payments|Literal",
+				"User:Alice|*|Describe|Allow|Topic|payments|Literal",
+				"User:Alice|*|Create|Allow|Topic|payments|Literal",
+			},
+		},
+		{
+			name:     "idempotent producers of different topics keep IdempotentWrite",
+			role:     producer,
+			current:  current(producer, refundsProducer),
+			expected: []string{"User:Alice|*|IdempotentWrite|Allow|Cluster|kafka-cluster|Literal"},
+		},
+		{
+			name:    "role on another host shares nothing",
+			role:    consumer,
+			current: current(consumer, otherHost),
+		},
+		{
+			name: "ACLs without a role marker share nothing",
+			role: consumer,
+			current: append(current(consumer), StringlyTypedACL{
+				ACL:      ACL{Principal: "User:Alice", Host: "*", Operation: "Write", PermissionType: "Allow"},
+				Resource: Resource{Type: "Topic", Name: "payments", PatternTypeFilter: "Literal"},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acls, err := tt.role.ACLs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			unshared, shared := tt.role.Unshared(acls, tt.current)
+			if !slices.Equal(strs(shared), tt.expected) {
+				t.Errorf("expected %v to be shared, got %v", tt.expected, strs(shared))
+			}
+			if len(unshared)+len(shared) != len(acls) {
+				t.Errorf("expected every ACL to be either shared or unshared, got %v and %v", unshared, shared)
+			}
+			marker, _ := tt.role.Marker()
+			if !slices.Contains(strs(unshared), marker.String()) {
+				t.Errorf("expected the marker of the role to be deleted, got %v", strs(unshared))
+			}
+		})
+	}
+}
+
+func TestACLRoleMarker(t *testing.T) {
+	role := ACLRole{Role: aclRoleConsumer, Principal: "User:Alice", Host: "*", Topic: "payments", TopicPatternType: "Prefixed", Group: "billing|eu", GroupPatternType: "Literal"}
+	marker, err := role.Marker()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, ok := aclRoleFromMarker(marker)
+	if !ok {
+		t.Fatalf("expected %v to be a role marker", marker)
+	}
+	if decoded != role {
+		t.Errorf("expected %v to decode to %+v, got %+v", marker, role, decoded)
+	}
+
+	if _, ok := aclRoleFromMarker(StringlyTypedACL{Resource: Resource{Type: "DelegationToken", Name: "token", PatternTypeFilter: "Literal"}}); ok {
+		t.Error("expected an ordinary delegation token ACL not to be a role marker")
+	}
+}
@@ -0,0 +1,416 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func kafkaACLSetResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: aclSetCreate,
+		ReadContext:   aclSetRead,
+		UpdateContext: aclSetUpdate,
+		DeleteContext: aclSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importACLSet,
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateACLSetOperations,
+		},
+		Description: "Manages a set of ACLs for a single principal as one unit.",
+		Schema: map[string]*schema.Schema{
+			"acl_principal": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The principal every ACL in the set applies to, e.g. User:Alice",
+			},
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, every other ACL of the principal is shown as drift and deleted on apply, including ACLs created outside of Terraform",
+			},
+			"acl": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The ACLs granted to or denied from the principal",
+				Elem:        aclSetACLResource(),
+			},
+		},
+	}
+}
+
+// aclSetACLResource is the schema of an acl block granted to a single principal
+func aclSetACLResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"resource_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the resource",
+			},
+			"resource_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiagFunc(validateACLEnum(aclResourceTypes)),
+				Description:      "The type of the resource",
+			},
+			"resource_pattern_type_filter": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "Literal",
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{"Literal", "Prefixed"}, false)),
+				Description:      "How to match the resource name. Valid values: Literal (exact match) or Prefixed (match resources with the given prefix).",
+			},
+			"acl_host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "*",
+				Description: "The host the principal is allowed or denied from",
+			},
+			"acl_operation": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiagFunc(validateACLEnum(aclOperations)),
+				Description:      "The operation that is allowed or denied",
+			},
+			"acl_permission_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "Allow",
+				ValidateDiagFunc: validateDiagFunc(validateACLEnum(aclPermissionTypes)),
+				Description:      "Whether the operation is allowed or denied",
+			},
+		},
+	}
+}
+
+func aclSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	principal := d.Get("acl_principal").(string)
+	acls := aclSetInfo(principal, d.Get("acl").(*schema.Set))
+
+	// Track the set before creating it, so ACLs created before a failure are
+	// still deleted on destroy
+	d.SetId(principal)
+
+	log.Printf("[INFO] Creating %d ACLs for %s", len(acls), principal)
+	if err := c.CreateACLs(acls); err != nil {
+		log.Println("[ERROR] Failed to create ACL set")
+		if applied := recordAppliedACLSet(c, d, principal, acls); len(applied) == 0 {
+			d.SetId("")
+		}
+		return diag.FromErr(err)
+	}
+
+	var unmanaged []StringlyTypedACL
+	if d.Get("exclusive").(bool) {
+		var err error
+		unmanaged, err = deleteUnmanagedACLs(c, principal, acls)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := waitForACLSet(ctx, c, principal, acls, unmanaged); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// recordAppliedACLSet sets acl to the ACLs of candidates found on the broker
+// after a batch failed partway, returning them. When the broker cannot be
+// asked every candidate is kept, since the next read drops the missing ones.
+func recordAppliedACLSet(c *LazyClient, d *schema.ResourceData, principal string, candidates []StringlyTypedACL) []StringlyTypedACL {
+	applied := candidates
+	if found, err := c.FilterACLs(StringlyTypedACL{ACL: ACL{Principal: principal}}); err != nil {
+		log.Printf("[WARN] Could not read the ACLs of %s after a failed change: %v", principal, err)
+	} else {
+		existing := make(map[string]bool, len(found))
+		for _, a := range found {
+			existing[a.String()] = true
+		}
+		applied = []StringlyTypedACL{}
+		for _, a := range candidates {
+			if existing[a.String()] {
+				applied = append(applied, a)
+			}
+		}
+	}
+
+	if err := d.Set("acl", flattenACLSet(applied)); err != nil {
+		log.Printf("[WARN] Could not record the ACLs of %s: %v", principal, err)
+	}
+	return applied
+}
+
+// deleteUnmanagedACLs deletes every ACL of the principal that is not in
+// managed, returning the deleted ACLs
+func deleteUnmanagedACLs(c *LazyClient, principal string, managed []StringlyTypedACL) ([]StringlyTypedACL, error) {
+	found, err := c.FilterACLs(StringlyTypedACL{ACL: ACL{Principal: principal}})
+	if err != nil {
+		return nil, err
+	}
+
+	unmanaged := aclSetUnmanaged(found, managed)
+	log.Printf("[INFO] Deleting %d unmanaged ACLs for %s", len(unmanaged), principal)
+	if err := c.DeleteACLs(unmanaged); err != nil {
+		return nil, err
+	}
+
+	return unmanaged, nil
+}
+
+// aclSetUnmanaged lists the found ACLs that are not part of the managed set
+func aclSetUnmanaged(found []StringlyTypedACL, managed []StringlyTypedACL) []StringlyTypedACL {
+	declared := make(map[string]bool, len(managed))
+	for _, a := range managed {
+		declared[a.String()] = true
+	}
+
+	unmanaged := []StringlyTypedACL{}
+	for _, a := range found {
+		if !declared[a.String()] {
+			unmanaged = append(unmanaged, a)
+		}
+	}
+	return unmanaged
+}
+
+func aclSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	principal := d.Get("acl_principal").(string)
+	log.Printf("[INFO] Reading ACL set for %s", principal)
+
+	found, err := c.FilterACLs(StringlyTypedACL{ACL: ACL{Principal: principal}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only keep the ACLs this resource manages, unless it is exclusive or is
+	// being imported and has nothing in state yet. Keeping every ACL of an
+	// exclusive set in state makes unmanaged ACLs show up as removals in the plan.
+	managed := map[string]bool{}
+	for _, a := range aclSetInfo(principal, d.Get("acl").(*schema.Set)) {
+		managed[a.String()] = true
+	}
+	exclusive := d.Get("exclusive").(bool)
+
+	current := []StringlyTypedACL{}
+	for _, a := range found {
+		if exclusive || len(managed) == 0 || managed[a.String()] {
+			current = append(current, a)
+		}
+	}
+
+	if len(current) == 0 {
+		log.Printf("[INFO] Did not find any ACLs for %s", principal)
+		d.SetId("")
+		return nil
+	}
+
+	errSet := errSetter{d: d}
+	errSet.Set("acl_principal", principal)
+	errSet.Set("acl", flattenACLSet(current))
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	return nil
+}
+
+func aclSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	principal := d.Get("acl_principal").(string)
+
+	o, n := d.GetChange("acl")
+	oldSet := o.(*schema.Set)
+	newSet := n.(*schema.Set)
+	added := aclSetInfo(principal, newSet.Difference(oldSet))
+	removed := aclSetInfo(principal, oldSet.Difference(newSet))
+
+	// A batch that fails partway leaves some ACLs created or deleted, so the
+	// ACLs of either set still on the broker are recorded. Those created stay
+	// tracked and those that failed to be deleted are retried.
+	failed := func(err error) diag.Diagnostics {
+		recordAppliedACLSet(c, d, principal, aclSetInfo(principal, oldSet.Union(newSet)))
+		return diag.FromErr(err)
+	}
+
+	// Create before deleting so a binding that is only being reshaped never
+	// leaves the principal without access
+	log.Printf("[INFO] Updating ACL set for %s: adding %d, removing %d", principal, len(added), len(removed))
+	if err := c.CreateACLs(added); err != nil {
+		return failed(err)
+	}
+	if err := c.DeleteACLs(removed); err != nil {
+		return failed(err)
+	}
+
+	// Also catch ACLs granted since the plan was made
+	if d.Get("exclusive").(bool) {
+		unmanaged, err := deleteUnmanagedACLs(c, principal, aclSetInfo(principal, newSet))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		removed = append(removed, unmanaged...)
+	}
+
+	if err := waitForACLSet(ctx, c, principal, added, removed); err != nil {
+		return failed(err)
+	}
+
+	return nil
+}
+
+func aclSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	principal := d.Get("acl_principal").(string)
+	acls := aclSetInfo(principal, d.Get("acl").(*schema.Set))
+
+	log.Printf("[INFO] Deleting %d ACLs for %s", len(acls), principal)
+	if err := c.DeleteACLs(acls); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForACLSet(ctx, c, principal, nil, acls); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// validateACLSetOperations warns about acl blocks with operations Kafka never
+// authorizes on their resource type
+func validateACLSetOperations(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	cfg := req.RawConfig
+	if cfg.IsNull() || !cfg.IsKnown() || !cfg.Type().IsObjectType() || !cfg.Type().HasAttribute("acl") {
+		return
+	}
+	acls := cfg.GetAttr("acl")
+	if acls.IsNull() || !acls.IsKnown() || !acls.CanIterateElements() {
+		return
+	}
+
+	for it := acls.ElementIterator(); it.Next(); {
+		key, acl := it.Element()
+		path := cty.Path{cty.GetAttrStep{Name: "acl"}, cty.IndexStep{Key: key}}
+		resp.Diagnostics = append(resp.Diagnostics, aclOperationDiagnostics(acl, path)...)
+	}
+}
+
+func importACLSet(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	errSet := errSetter{d: d}
+	errSet.Set("acl_principal", d.Id())
+	errSet.Set("exclusive", false)
+	if errSet.err != nil {
+		return nil, errSet.err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForACLSet waits until every ACL in present is visible and every ACL in
+// absent is gone, using a single DescribeAcls request for the principal per
+// attempt
+func waitForACLSet(ctx context.Context, c *LazyClient, principal string, present []StringlyTypedACL, absent []StringlyTypedACL) error {
+	refresh := func() (interface{}, string, error) {
+		found, err := c.FilterACLs(StringlyTypedACL{ACL: ACL{Principal: principal}})
+		if err != nil {
+			return nil, "Error", err
+		}
+
+		pending := aclSetPending(found, present, absent)
+		if len(pending) > 0 {
+			log.Printf("[DEBUG] Waiting for ACLs %v", pending)
+			return found, "Pending", nil
+		}
+		return found, "Ready", nil
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{"Pending"},
+		Target:       []string{"Ready"},
+		Refresh:      refresh,
+		Timeout:      time.Duration(c.Config.Timeout) * time.Second,
+		Delay:        200 * time.Millisecond,
+		PollInterval: 500 * time.Millisecond,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for ACLs of %s to be updated: %s", principal, err)
+	}
+
+	return nil
+}
+
+// aclSetPending lists the ACLs that should be present but are not found, and
+// the ACLs that should be absent but are still found
+func aclSetPending(found []StringlyTypedACL, present []StringlyTypedACL, absent []StringlyTypedACL) []string {
+	existing := make(map[string]bool, len(found))
+	for _, a := range found {
+		existing[a.String()] = true
+	}
+
+	pending := []string{}
+	for _, a := range present {
+		if !existing[a.String()] {
+			pending = append(pending, "create "+a.String())
+		}
+	}
+	for _, a := range absent {
+		if existing[a.String()] {
+			pending = append(pending, "delete "+a.String())
+		}
+	}
+	return pending
+}
+
+func aclSetInfo(principal string, set *schema.Set) []StringlyTypedACL {
+	acls := make([]StringlyTypedACL, 0, set.Len())
+	for _, v := range set.List() {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		acls = append(acls, StringlyTypedACL{
+			ACL: ACL{
+				Principal:      principal,
+				Host:           m["acl_host"].(string),
+				Operation:      m["acl_operation"].(string),
+				PermissionType: m["acl_permission_type"].(string),
+			},
+			Resource: Resource{
+				Type:              m["resource_type"].(string),
+				Name:              m["resource_name"].(string),
+				PatternTypeFilter: m["resource_pattern_type_filter"].(string),
+			},
+		})
+	}
+	return acls
+}
+
+func flattenACLSet(acls []StringlyTypedACL) []interface{} {
+	list := make([]interface{}, 0, len(acls))
+	for _, a := range acls {
+		list = append(list, map[string]interface{}{
+			"resource_name":                a.Name,
+			"resource_type":                a.Type,
+			"resource_pattern_type_filter": a.PatternTypeFilter,
+			"acl_host":                     a.ACL.Host,
+			"acl_operation":                a.ACL.Operation,
+			"acl_permission_type":          a.ACL.PermissionType,
+		})
+	}
+	return list
+}
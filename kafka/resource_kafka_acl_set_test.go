@@ -0,0 +1,213 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAcc_ACLSetCreateAndUpdate(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal := fmt.Sprintf("User:acl-set-%s", u)
+	topicName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      func(s *terraform.State) error { return testAccCheckACLSetDestroy(principal) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceACLSet_initialConfig, principal, topicName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_acl_set.test", "id", principal),
+					r.TestCheckResourceAttr("kafka_acl_set.test", "acl.#", "2"),
+					testAccCheckACLSetCount(principal, 2),
+				),
+			},
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceACLSet_updateConfig, principal, topicName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_acl_set.test", "acl.#", "2"),
+					r.TestCheckTypeSetElemNestedAttrs("kafka_acl_set.test", "acl.*", map[string]string{
+						"resource_type": "Group",
+						"acl_operation": "Read",
+					}),
+					testAccCheckACLSetCount(principal, 2),
+				),
+			},
+			{
+				ResourceName:      "kafka_acl_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAcc_ACLSetExclusive(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal := fmt.Sprintf("User:acl-set-%s", u)
+	topicName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	unmanaged := StringlyTypedACL{
+		ACL: ACL{
+			Principal:      principal,
+			Host:           "*",
+			Operation:      "Write",
+			PermissionType: "Allow",
+		},
+		Resource: Resource{
+			Type:              "Topic",
+			Name:              topicName,
+			PatternTypeFilter: "Literal",
+		},
+	}
+	config := cfg(t, bs, fmt.Sprintf(testResourceACLSet_exclusiveConfig, principal, topicName))
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      func(s *terraform.State) error { return testAccCheckACLSetDestroy(principal) },
+		Steps: []r.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckACLSetCount(principal, 1),
+			},
+			{
+				Config: config,
+				PreConfig: func() {
+					client := testProvider.Meta().(*LazyClient)
+					if err := client.CreateACLs([]StringlyTypedACL{unmanaged}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_acl_set.test", "acl.#", "1"),
+					testAccCheckACLSetCount(principal, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestACLSetUnmanaged(t *testing.T) {
+	read := StringlyTypedACL{
+		ACL:      ACL{Principal: "User:Alice", Host: "*", Operation: "Read", PermissionType: "Allow"},
+		Resource: Resource{Type: "Topic", Name: "payments", PatternTypeFilter: "Literal"},
+	}
+	write := read
+	write.ACL.Operation = "Write"
+
+	unmanaged := aclSetUnmanaged([]StringlyTypedACL{read, write}, []StringlyTypedACL{read})
+	if len(unmanaged) != 1 || unmanaged[0].String() != write.String() {
+		t.Errorf("expected only %s to be unmanaged, got %v", write, unmanaged)
+	}
+}
+
+func TestACLSetPending(t *testing.T) {
+	read := StringlyTypedACL{
+		ACL:      ACL{Principal: "User:Alice", Host: "*", Operation: "Read", PermissionType: "Allow"},
+		Resource: Resource{Type: "Topic", Name: "payments", PatternTypeFilter: "Literal"},
+	}
+	write := read
+	write.ACL.Operation = "Write"
+
+	if pending := aclSetPending([]StringlyTypedACL{read}, []StringlyTypedACL{read}, []StringlyTypedACL{write}); len(pending) != 0 {
+		t.Errorf("expected nothing to be pending, got %v", pending)
+	}
+
+	pending := aclSetPending([]StringlyTypedACL{write}, []StringlyTypedACL{read}, []StringlyTypedACL{write})
+	if len(pending) != 2 {
+		t.Errorf("expected the creation and the deletion to be pending, got %v", pending)
+	}
+}
+
+func testAccCheckACLSetCount(principal string, expected int) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*LazyClient)
+		acls, err := client.FilterACLs(StringlyTypedACL{ACL: ACL{Principal: principal}})
+		if err != nil {
+			return err
+		}
+		if len(acls) != expected {
+			return fmt.Errorf("expected %d ACLs for %s, got %d: %v", expected, principal, len(acls), acls)
+		}
+		return nil
+	}
+}
+
+func testAccCheckACLSetDestroy(principal string) error {
+	meta := testProvider.Meta()
+	if meta == nil {
+		return fmt.Errorf("provider Meta() returned nil")
+	}
+
+	return testAccCheckACLSetCount(principal, 0)(nil)
+}
+
+const testResourceACLSet_initialConfig = `
+resource "kafka_acl_set" "test" {
+  acl_principal = "%[1]s"
+
+  acl {
+    resource_name = "%[2]s"
+    resource_type = "Topic"
+    acl_operation = "Read"
+  }
+
+  acl {
+    resource_name = "%[2]s"
+    resource_type = "Topic"
+    acl_operation = "Describe"
+  }
+}
+`
+
+const testResourceACLSet_updateConfig = `
+resource "kafka_acl_set" "test" {
+  acl_principal = "%[1]s"
+
+  acl {
+    resource_name = "%[2]s"
+    resource_type = "Topic"
+    acl_operation = "Read"
+  }
+
+  acl {
+    resource_name = "%[2]s"
+    resource_type = "Group"
+    acl_operation = "Read"
+  }
+}
+`
+
+const testResourceACLSet_exclusiveConfig = `
+resource "kafka_acl_set" "test" {
+  acl_principal = "%[1]s"
+  exclusive     = true
+
+  acl {
+    resource_name = "%[2]s"
+    resource_type = "Topic"
+    acl_operation = "Read"
+  }
+}
+`
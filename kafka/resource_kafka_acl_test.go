@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/hashicorp/go-cty/cty"
 	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -100,6 +103,153 @@ func TestAcc_ACLDeletedOutsideOfTerraform(t *testing.T) {
 	})
 }
 
+func TestAcc_ACLUpdateInPlace(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	aclResourceName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      func(s *terraform.State) error { return testAccCheckAclDestroy(aclResourceName) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceACL_initialConfig, aclResourceName)),
+				Check:  testResourceACL_initialCheck,
+			},
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceACL_hostConfig, aclResourceName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_acl.test", "id", fmt.Sprintf("User:Alice|10.0.0.1|Write|Allow|Topic|%s|Literal", aclResourceName)),
+					testAccCheckACLCount(aclResourceName, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_ACLDistinguishedNamePrincipal(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	aclResourceName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      func(s *terraform.State) error { return testAccCheckAclDestroy(aclResourceName) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceACL_dnConfig, aclResourceName, "CN = alice, O = Example, C = US")),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_acl.test", "acl_principal", "User:CN=alice,O=Example,C=US"),
+					testAccCheckACLPrincipal(aclResourceName, "User:CN=alice,O=Example,C=US"),
+					testAccCheckACLCount(aclResourceName, 1),
+				),
+			},
+			{
+				// The same identity with lower-case keywords normalises to the same principal
+				Config:   cfg(t, bs, fmt.Sprintf(testResourceACL_dnConfig, aclResourceName, "cn=alice,o=Example,c=US")),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAcc_ACLHostRange(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	aclResourceName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      func(s *terraform.State) error { return testAccCheckAclDestroy(aclResourceName) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceACL_rangeConfig, aclResourceName, "10.0.0.0/30", 256)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_acl.test", "id", fmt.Sprintf("User:Alice|10.0.0.0/30|Write|Allow|Topic|%s|Literal", aclResourceName)),
+					r.TestCheckResourceAttr("kafka_acl.test", "acl_host_addresses.#", "4"),
+					r.TestCheckResourceAttr("kafka_acl.test", "acl_host_addresses.3", "10.0.0.3"),
+					testAccCheckACLCount(aclResourceName, 4),
+				),
+			},
+			{
+				// Removing one address outside Terraform is drift
+				PreConfig: func() {
+					client := testProvider.Meta().(*LazyClient)
+					err := client.DeleteACL(StringlyTypedACL{
+						ACL:      ACL{Principal: "User:Alice", Host: "10.0.0.2", Operation: "Write", PermissionType: "Allow"},
+						Resource: Resource{Type: "Topic", Name: aclResourceName, PatternTypeFilter: "Literal"},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             cfg(t, bs, fmt.Sprintf(testResourceACL_rangeConfig, aclResourceName, "10.0.0.0/30", 256)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Recreates the missing address and deletes the ones outside the new range
+				Config: cfg(t, bs, fmt.Sprintf(testResourceACL_rangeConfig, aclResourceName, "10.0.0.2/31", 256)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_acl.test", "acl_host_addresses.#", "2"),
+					testAccCheckACLCount(aclResourceName, 2),
+				),
+			},
+			{
+				Config:      cfg(t, bs, fmt.Sprintf(testResourceACL_rangeConfig, aclResourceName, "10.0.0.0/24", 16)),
+				ExpectError: regexp.MustCompile("more than acl_host_max_addresses"),
+			},
+		},
+	})
+}
+
+func testAccCheckACLCount(name string, expected int) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*LazyClient)
+		acls, err := client.FilterACLs(StringlyTypedACL{Resource: Resource{Name: name}})
+		if err != nil {
+			return err
+		}
+		if len(acls) != expected {
+			return fmt.Errorf("expected %d ACLs for %s, got %d: %v", expected, name, len(acls), acls)
+		}
+		return nil
+	}
+}
+
+// testAccCheckACLPrincipal checks the principal of every ACL on the resource
+// is exactly the one Kafka will compare the client's principal with
+func testAccCheckACLPrincipal(name string, expected string) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*LazyClient)
+		acls, err := client.FilterACLs(StringlyTypedACL{Resource: Resource{Name: name}})
+		if err != nil {
+			return err
+		}
+		for _, a := range acls {
+			if a.ACL.Principal != expected {
+				return fmt.Errorf("expected ACL principal %q, got %q", expected, a.ACL.Principal)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckAclDestroy(name string) error {
 	meta := testProvider.Meta()
 	if meta == nil {
@@ -276,6 +426,41 @@ resource "kafka_acl" "test" {
 }
 `
 
+const testResourceACL_hostConfig = `
+resource "kafka_acl" "test" {
+	resource_name       = "%s"
+	resource_type       = "Topic"
+	resource_pattern_type_filter = "Literal"
+	acl_principal       = "User:Alice"
+	acl_host            = "10.0.0.1"
+	acl_operation       = "Write"
+	acl_permission_type = "Allow"
+}
+`
+
+const testResourceACL_dnConfig = `
+resource "kafka_acl" "test" {
+	resource_name       = "%s"
+	resource_type       = "Topic"
+	acl_principal       = "User:%s"
+	acl_host            = "*"
+	acl_operation       = "Read"
+	acl_permission_type = "Allow"
+}
+`
+
+const testResourceACL_rangeConfig = `
+resource "kafka_acl" "test" {
+	resource_name          = "%s"
+	resource_type          = "Topic"
+	acl_principal          = "User:Alice"
+	acl_host               = "%s"
+	acl_host_max_addresses = %d
+	acl_operation          = "Write"
+	acl_permission_type    = "Allow"
+}
+`
+
 // lintignore:AT004
 func cfg(t *testing.T, bs string, extraCfg string) string {
 	_, err := os.ReadFile("../secrets/ca.crt")
@@ -299,3 +484,55 @@ provider "kafka" {
 %s
 `, bs, extraCfg)
 }
+
+func TestACLOperationDiagnostics(t *testing.T) {
+	config := func(resourceType, operation cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"resource_type": resourceType,
+			"acl_operation": operation,
+		})
+	}
+
+	diags := aclOperationDiagnostics(config(cty.StringVal("Cluster"), cty.StringVal("Read")), cty.Path{})
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning for Read on a Cluster, got %v", diags)
+	}
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("acl_operation")) {
+		t.Errorf("expected the warning to point at acl_operation, got %v", diags[0].AttributePath)
+	}
+
+	if diags := aclOperationDiagnostics(config(cty.StringVal("Topic"), cty.StringVal("Read")), cty.Path{}); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for Read on a Topic, got %v", diags)
+	}
+	if diags := aclOperationDiagnostics(config(cty.UnknownVal(cty.String), cty.StringVal("Read")), cty.Path{}); len(diags) != 0 {
+		t.Errorf("expected unknown values to be skipped, got %v", diags)
+	}
+}
+
+func TestAcc_ACLInvalidOperation(t *testing.T) {
+	t.Parallel()
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config:      cfg(t, bs, testResourceACL_typoConfig),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("did you mean Read"),
+			},
+		},
+	})
+}
+
+const testResourceACL_typoConfig = `
+resource "kafka_acl" "test" {
+	resource_name       = "syslog"
+	resource_type       = "Topic"
+	acl_principal       = "User:Alice"
+	acl_host            = "*"
+	acl_operation       = "Reed"
+	acl_permission_type = "Allow"
+}
+`
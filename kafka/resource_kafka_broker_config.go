@@ -0,0 +1,207 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// clusterDefaultBrokerConfigID is the ID of the cluster-wide default broker
+// config, which Kafka addresses with an empty broker id
+const clusterDefaultBrokerConfigID = "cluster-default"
+
+func kafkaBrokerConfigResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: brokerConfigCreate,
+		ReadContext:   brokerConfigRead,
+		UpdateContext: brokerConfigUpdate,
+		DeleteContext: brokerConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importBrokerConfig,
+		},
+		CustomizeDiff: brokerConfigCustomDiff,
+		Description:   "Manages the dynamic configs of a broker, or the cluster-wide defaults of every broker.",
+		Schema: map[string]*schema.Schema{
+			"broker_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDiagFunc(validation.StringMatch(brokerIDPattern, "must be a broker id")),
+				Description:      "The id of the broker. When omitted the configs are the cluster-wide defaults of every broker.",
+			},
+			"config": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "A map of dynamic broker configs, e.g. log.cleaner.threads. Dynamic configs of the broker not listed are shown as drift.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sensitive_config": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A map of dynamic broker configs Kafka does not return, such as listener keystore passwords. Only their presence is checked on read.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func brokerConfigCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	brokerID := d.Get("broker_id").(string)
+	config := brokerConfig(d.Get("config"), d.Get("sensitive_config"))
+	log.Printf("[INFO] Creating %s", brokerConfigName(brokerID))
+
+	if err := c.AlterBrokerConfig(brokerID, config, nil); err != nil {
+		log.Println("[ERROR] Failed to create broker config")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(brokerConfigID(brokerID))
+	return brokerConfigRead(ctx, d, meta)
+}
+
+func brokerConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	brokerID := d.Get("broker_id").(string)
+	log.Printf("[INFO] Reading %s", brokerConfigName(brokerID))
+
+	found, err := c.DescribeBrokerConfig(brokerID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config, sensitiveConfig := flattenBrokerConfig(found, d.Get("config").(map[string]interface{}), d.Get("sensitive_config").(map[string]interface{}))
+
+	errSet := errSetter{d: d}
+	errSet.Set("config", config)
+	errSet.Set("sensitive_config", sensitiveConfig)
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	return nil
+}
+
+func brokerConfigUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	brokerID := d.Get("broker_id").(string)
+
+	oldConfig, newConfig := d.GetChange("config")
+	oldSensitive, newSensitive := d.GetChange("sensitive_config")
+	config := brokerConfig(newConfig, newSensitive)
+	deleted := []string{}
+	for key := range brokerConfig(oldConfig, oldSensitive) {
+		if _, ok := config[key]; !ok {
+			deleted = append(deleted, key)
+		}
+	}
+	sort.Strings(deleted)
+
+	log.Printf("[INFO] Updating %s, removing %v", brokerConfigName(brokerID), deleted)
+	if err := c.AlterBrokerConfig(brokerID, config, deleted); err != nil {
+		log.Println("[ERROR] Failed to update broker config")
+		return diag.FromErr(err)
+	}
+
+	return brokerConfigRead(ctx, d, meta)
+}
+
+// brokerConfigDelete removes every managed config, reverting the broker to the
+// cluster-wide default or to server.properties
+func brokerConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	brokerID := d.Get("broker_id").(string)
+
+	deleted := []string{}
+	for key := range brokerConfig(d.Get("config"), d.Get("sensitive_config")) {
+		deleted = append(deleted, key)
+	}
+	sort.Strings(deleted)
+
+	log.Printf("[INFO] Deleting %s: %v", brokerConfigName(brokerID), deleted)
+	if err := c.AlterBrokerConfig(brokerID, nil, deleted); err != nil {
+		log.Println("[ERROR] Failed to delete broker config")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func importBrokerConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	brokerID := d.Id()
+	if brokerID == clusterDefaultBrokerConfigID {
+		brokerID = ""
+	} else if !brokerIDPattern.MatchString(brokerID) {
+		return nil, fmt.Errorf("failed importing resource; expected a broker id or %s, got %q", clusterDefaultBrokerConfigID, brokerID)
+	}
+
+	if err := d.Set("broker_id", brokerID); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func brokerConfigCustomDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if !diff.NewValueKnown("config") || !diff.NewValueKnown("sensitive_config") {
+		return nil
+	}
+
+	sensitive := diff.Get("sensitive_config").(map[string]interface{})
+	for key := range diff.Get("config").(map[string]interface{}) {
+		if _, ok := sensitive[key]; ok {
+			return fmt.Errorf("%s is set in both config and sensitive_config", key)
+		}
+	}
+	return nil
+}
+
+func brokerConfigID(brokerID string) string {
+	if brokerID == "" {
+		return clusterDefaultBrokerConfigID
+	}
+	return brokerID
+}
+
+// brokerConfig merges config and sensitive_config into the configs to set
+func brokerConfig(config interface{}, sensitiveConfig interface{}) map[string]*string {
+	merged := map[string]*string{}
+	for _, m := range []interface{}{config, sensitiveConfig} {
+		values, _ := m.(map[string]interface{})
+		for key, value := range values {
+			if value, ok := value.(string); ok {
+				merged[key] = &value
+			}
+		}
+	}
+	return merged
+}
+
+// flattenBrokerConfig splits the dynamic configs found on the broker into
+// config and sensitive_config. Kafka never returns sensitive values, so the
+// configured value is kept for those that are still set, and sensitive configs
+// that are not managed are left out.
+func flattenBrokerConfig(found map[string]*string, config map[string]interface{}, sensitiveConfig map[string]interface{}) (map[string]string, map[string]string) {
+	flatConfig := map[string]string{}
+	flatSensitive := map[string]string{}
+	for key, value := range found {
+		if v, ok := sensitiveConfig[key].(string); ok {
+			flatSensitive[key] = v
+			continue
+		}
+		if value == nil {
+			if v, ok := config[key].(string); ok {
+				flatConfig[key] = v
+			}
+			continue
+		}
+		flatConfig[key] = *value
+	}
+	return flatConfig, flatSensitive
+}
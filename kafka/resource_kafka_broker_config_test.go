@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAcc_BrokerConfig(t *testing.T) {
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckBrokerConfigDestroy,
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, testResourceBrokerConfig_initialConfig),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_broker_config.broker", "id", "1"),
+					r.TestCheckResourceAttr("kafka_broker_config.broker", "config.log.cleaner.threads", "2"),
+					r.TestCheckResourceAttr("kafka_broker_config.default", "id", clusterDefaultBrokerConfigID),
+					r.TestCheckResourceAttr("kafka_broker_config.default", "config.max.connections", "10000"),
+					testAccCheckBrokerConfig("1", "log.cleaner.threads", "2"),
+					testAccCheckBrokerConfig("", "max.connections", "10000"),
+				),
+			},
+			{
+				Config: cfg(t, bs, testResourceBrokerConfig_updateConfig),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_broker_config.broker", "config.%", "1"),
+					r.TestCheckResourceAttr("kafka_broker_config.broker", "config.max.connections", "5000"),
+					testAccCheckBrokerConfig("1", "log.cleaner.threads", ""),
+					testAccCheckBrokerConfig("1", "max.connections", "5000"),
+				),
+			},
+			{
+				ResourceName:      "kafka_broker_config.broker",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "kafka_broker_config.default",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckBrokerConfig checks the dynamic value of a config, where an
+// empty value means it is not set
+func testAccCheckBrokerConfig(brokerID string, key string, expected string) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*LazyClient)
+		config, err := client.DescribeBrokerConfig(brokerID)
+		if err != nil {
+			return err
+		}
+
+		actual := ""
+		if v, ok := config[key]; ok && v != nil {
+			actual = *v
+		}
+		if actual != expected {
+			return fmt.Errorf("expected %s of %s to be %q, got %q", key, brokerConfigName(brokerID), expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccCheckBrokerConfigDestroy(s *terraform.State) error {
+	for _, check := range []r.TestCheckFunc{
+		testAccCheckBrokerConfig("1", "max.connections", ""),
+		testAccCheckBrokerConfig("", "max.connections", ""),
+	} {
+		if err := check(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const testResourceBrokerConfig_initialConfig = `
+resource "kafka_broker_config" "broker" {
+  broker_id = 1
+
+  config = {
+    "log.cleaner.threads" = "2"
+  }
+}
+
+resource "kafka_broker_config" "default" {
+  config = {
+    "max.connections" = "10000"
+  }
+}
+`
+
+const testResourceBrokerConfig_updateConfig = `
+resource "kafka_broker_config" "broker" {
+  broker_id = 1
+
+  config = {
+    "max.connections" = "5000"
+  }
+}
+
+resource "kafka_broker_config" "default" {
+  config = {
+    "max.connections" = "10000"
+  }
+}
+`
@@ -0,0 +1,233 @@
+package kafka
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var brokerLoggerLevels = []string{"OFF", "FATAL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE", "ALL"}
+
+var brokerLoggerLevelPattern = regexp.MustCompile(`^(` + strings.Join(brokerLoggerLevels, "|") + `)$`)
+
+// rootLoggerName is the name Kafka lists the root logger under. Loggers
+// without a level of their own are listed with the root logger's level.
+const rootLoggerName = "root"
+
+func kafkaBrokerLoggerResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: brokerLoggerCreate,
+		ReadContext:   brokerLoggerRead,
+		UpdateContext: brokerLoggerUpdate,
+		DeleteContext: brokerLoggerDelete,
+		Description:   "Sets the level of log4j loggers on a broker, restoring the previous levels on destroy.",
+		Schema: map[string]*schema.Schema{
+			"broker_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDiagFunc(validation.StringMatch(brokerIDPattern, "must be a broker id")),
+				Description:      "The id of the broker",
+			},
+			"levels": {
+				Type:             schema.TypeMap,
+				Required:         true,
+				ValidateDiagFunc: validation.MapValueMatch(brokerLoggerLevelPattern, "must be one of "+strings.Join(brokerLoggerLevels, ", ")),
+				Description:      "A map of logger name to level, e.g. kafka.authorizer.logger = DEBUG",
+				Elem:             &schema.Schema{Type: schema.TypeString},
+			},
+			"previous_levels": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The level of each logger before it was first set, restored when it is removed from levels or the resource is destroyed. Loggers that inherited the root logger's level are empty and are unset instead, so they inherit it again.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func brokerLoggerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	brokerID := d.Get("broker_id").(string)
+	levels := stringMap(d.Get("levels"))
+
+	current, err := c.DescribeBrokerLoggers(brokerID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	previous := brokerLoggerPrevious(current, nil, sortedKeys(levels))
+
+	log.Printf("[INFO] Setting loggers of broker %s to %v, previously %v", brokerID, levels, previous)
+	if err := c.AlterBrokerLoggers(brokerID, levels, nil); err != nil {
+		log.Println("[ERROR] Failed to set broker loggers")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(brokerID)
+	if err := d.Set("previous_levels", previous); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return brokerLoggerRead(ctx, d, meta)
+}
+
+func brokerLoggerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	brokerID := d.Get("broker_id").(string)
+	log.Printf("[INFO] Reading loggers of broker %s", brokerID)
+
+	current, err := c.DescribeBrokerLoggers(brokerID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A logger that is no longer listed, such as after a broker restart, has
+	// the root logger's level, so the configured level is planned again
+	levels := stringMap(d.Get("levels"))
+	for logger := range levels {
+		if level, ok := current[logger]; ok {
+			levels[logger] = level
+		} else {
+			levels[logger] = current[rootLoggerName]
+		}
+	}
+
+	if err := d.Set("levels", levels); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func brokerLoggerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	brokerID := d.Get("broker_id").(string)
+
+	o, n := d.GetChange("levels")
+	oldLevels := stringMap(o)
+	levels := stringMap(n)
+	previous := stringMap(d.Get("previous_levels"))
+
+	added := []string{}
+	for logger := range levels {
+		if _, ok := oldLevels[logger]; !ok {
+			added = append(added, logger)
+		}
+	}
+	removed := []string{}
+	for logger := range oldLevels {
+		if _, ok := levels[logger]; !ok {
+			removed = append(removed, logger)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	if len(added) > 0 {
+		current, err := c.DescribeBrokerLoggers(brokerID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		previous = brokerLoggerPrevious(current, previous, added)
+	}
+
+	restored, unset := brokerLoggerRestore(previous, removed)
+	for logger, level := range levels {
+		restored[logger] = level
+	}
+
+	log.Printf("[INFO] Setting loggers of broker %s to %v, unsetting %v", brokerID, restored, unset)
+	if err := c.AlterBrokerLoggers(brokerID, restored, unset); err != nil {
+		log.Println("[ERROR] Failed to update broker loggers")
+		return diag.FromErr(err)
+	}
+
+	for _, logger := range removed {
+		delete(previous, logger)
+	}
+	if err := d.Set("previous_levels", previous); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return brokerLoggerRead(ctx, d, meta)
+}
+
+func brokerLoggerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	brokerID := d.Get("broker_id").(string)
+
+	restored, unset := brokerLoggerRestore(stringMap(d.Get("previous_levels")), sortedKeys(stringMap(d.Get("levels"))))
+
+	log.Printf("[INFO] Restoring loggers of broker %s to %v, unsetting %v", brokerID, restored, unset)
+	if err := c.AlterBrokerLoggers(brokerID, restored, unset); err != nil {
+		log.Println("[ERROR] Failed to restore broker loggers")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// brokerLoggerPrevious records the current level of each logger that has no
+// previous level yet. Kafka lists loggers without a level of their own with
+// the root logger's level, so those, like loggers log4j does not know about,
+// are recorded as empty. A logger explicitly set to the root logger's level
+// cannot be told apart and is unset on restore, leaving its level unchanged
+// unless the root level changes.
+func brokerLoggerPrevious(current map[string]string, previous map[string]string, loggers []string) map[string]string {
+	recorded := make(map[string]string, len(previous)+len(loggers))
+	for logger, level := range previous {
+		recorded[logger] = level
+	}
+	for _, logger := range loggers {
+		if _, ok := recorded[logger]; ok {
+			continue
+		}
+		if level := current[logger]; logger == rootLoggerName || level != current[rootLoggerName] {
+			recorded[logger] = level
+		} else {
+			recorded[logger] = ""
+		}
+	}
+	return recorded
+}
+
+// brokerLoggerRestore returns the previous levels of loggers to set, and the
+// loggers without a previous level to unset
+func brokerLoggerRestore(previous map[string]string, loggers []string) (map[string]string, []string) {
+	restored := map[string]string{}
+	unset := []string{}
+	for _, logger := range loggers {
+		if level := previous[logger]; level != "" {
+			restored[logger] = level
+		} else {
+			unset = append(unset, logger)
+		}
+	}
+	return restored, unset
+}
+
+func stringMap(v interface{}) map[string]string {
+	m, _ := v.(map[string]interface{})
+	values := make(map[string]string, len(m))
+	for key, value := range m {
+		if value, ok := value.(string); ok {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,135 @@
+package kafka
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAcc_BrokerLogger(t *testing.T) {
+	bs := testBootstrapServers[0]
+	var before map[string]string
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+			var err error
+			before, err = testProvider.Meta().(*LazyClient).DescribeBrokerLoggers("1")
+			if err != nil {
+				t.Fatal(err)
+			}
+		},
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckBrokerLogger("1", "kafka.authorizer.logger", before["kafka.authorizer.logger"])(s)
+		},
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, testResourceBrokerLogger_initialConfig),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_broker_logger.test", "id", "1"),
+					r.TestCheckResourceAttr("kafka_broker_logger.test", "levels.kafka.authorizer.logger", "DEBUG"),
+					r.TestCheckResourceAttr("kafka_broker_logger.test", "previous_levels.%", "1"),
+					testAccCheckBrokerLogger("1", "kafka.authorizer.logger", "DEBUG"),
+				),
+			},
+			{
+				Config: cfg(t, bs, testResourceBrokerLogger_updateConfig),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_broker_logger.test", "levels.kafka.authorizer.logger", "TRACE"),
+					r.TestCheckResourceAttr("kafka_broker_logger.test", "levels.kafka.request.logger", "DEBUG"),
+					testAccCheckBrokerLogger("1", "kafka.authorizer.logger", "TRACE"),
+					testAccCheckBrokerLogger("1", "kafka.request.logger", "DEBUG"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBrokerLogger(brokerID string, logger string, expected string) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*LazyClient)
+		levels, err := client.DescribeBrokerLoggers(brokerID)
+		if err != nil {
+			return err
+		}
+		if levels[logger] != expected {
+			return fmt.Errorf("expected %s on broker %s to be %q, got %q", logger, brokerID, expected, levels[logger])
+		}
+		return nil
+	}
+}
+
+func TestBrokerLoggerPrevious(t *testing.T) {
+	current := map[string]string{
+		"root":                    "INFO",
+		"kafka.authorizer.logger": "DEBUG",
+		"kafka.request.logger":    "WARN",
+		"kafka.network":           "INFO",
+	}
+	previous := map[string]string{"kafka.authorizer.logger": "INFO"}
+
+	recorded := brokerLoggerPrevious(current, previous, []string{"kafka.authorizer.logger", "kafka.request.logger", "kafka.network", "kafka.unknown", "root"})
+	expected := map[string]string{
+		"kafka.authorizer.logger": "INFO",
+		"kafka.request.logger":    "WARN",
+		// Listed with the root logger's level, so inherited
+		"kafka.network": "",
+		"kafka.unknown": "",
+		"root":          "INFO",
+	}
+	if !reflect.DeepEqual(recorded, expected) {
+		t.Errorf("expected %v, got %v", expected, recorded)
+	}
+	if previous["kafka.request.logger"] != "" {
+		t.Errorf("expected previous levels not to be modified, got %v", previous)
+	}
+}
+
+func TestBrokerLoggerRestoreInherited(t *testing.T) {
+	current := map[string]string{"root": "INFO", "kafka.network": "INFO"}
+	recorded := brokerLoggerPrevious(current, nil, []string{"kafka.network"})
+	restored, unset := brokerLoggerRestore(recorded, []string{"kafka.network"})
+	if len(restored) != 0 || !reflect.DeepEqual(unset, []string{"kafka.network"}) {
+		t.Errorf("expected an inherited logger to be unset rather than pinned, got %v and %v", restored, unset)
+	}
+}
+
+func TestBrokerLoggerRestore(t *testing.T) {
+	previous := map[string]string{
+		"kafka.authorizer.logger": "INFO",
+		"kafka.unknown":           "",
+	}
+
+	restored, unset := brokerLoggerRestore(previous, []string{"kafka.authorizer.logger", "kafka.unknown", "kafka.untracked"})
+	if expected := map[string]string{"kafka.authorizer.logger": "INFO"}; !reflect.DeepEqual(restored, expected) {
+		t.Errorf("expected to restore %v, got %v", expected, restored)
+	}
+	if expected := []string{"kafka.unknown", "kafka.untracked"}; !reflect.DeepEqual(unset, expected) {
+		t.Errorf("expected to unset %v, got %v", expected, unset)
+	}
+}
+
+const testResourceBrokerLogger_initialConfig = `
+resource "kafka_broker_logger" "test" {
+  broker_id = 1
+
+  levels = {
+    "kafka.authorizer.logger" = "DEBUG"
+  }
+}
+`
+
+const testResourceBrokerLogger_updateConfig = `
+resource "kafka_broker_logger" "test" {
+  broker_id = 1
+
+  levels = {
+    "kafka.authorizer.logger" = "TRACE"
+    "kafka.request.logger"    = "DEBUG"
+  }
+}
+`
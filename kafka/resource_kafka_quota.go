@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -18,20 +19,50 @@ func kafkaQuotaResource() *schema.Resource {
 		CreateContext: quotaCreate,
 		ReadContext:   quotaRead,
 		DeleteContext: quotaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importQuota,
+		},
+		CustomizeDiff: quotaCustomDiff,
 		Schema: map[string]*schema.Schema{
 			"entity_name": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "The name of the entity (if entity_name is not provided, it will create entity-default Kafka quota)",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"entity"},
+				Description:   "The name of the entity (if entity_name is not provided, it will create entity-default Kafka quota)",
 			},
 			"entity_type": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
 				ForceNew:         true,
+				ExactlyOneOf:     []string{"entity_type", "entity"},
 				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{"client-id", "user", "ip"}, false)),
 				Description:      "The type of the entity (client-id, user, ip)",
 			},
+			"entity": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    2,
+				Description: "The components of a composite entity, such as a user and client-id pair. Conflicts with entity_name and entity_type.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{"client-id", "user", "ip"}, false)),
+							Description:      "The type of the entity component (client-id, user, ip)",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The name of the entity component (if name is not provided, the default entity of that type is used)",
+						},
+					},
+				},
+			},
 			"config": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -74,7 +105,7 @@ func quotaCreate(ctx context.Context, d *schema.ResourceData, meta interface{})
 
 func quotaCreatedFunc(client *LazyClient, q Quota) retry.StateRefreshFunc {
 	return func() (result interface{}, s string, err error) {
-		fq, err := client.DescribeQuota(q.EntityType, q.EntityName)
+		fq, err := client.DescribeQuota(q.Entity)
 		switch e := err.(type) {
 		case QuotaMissingError:
 			return fq, "Pending", nil
@@ -104,11 +135,10 @@ func quotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	log.Println("[INFO] Reading Quota")
 	c := meta.(*LazyClient)
 
-	entityType := d.Get("entity_type").(string)
-	entityName := d.Get("entity_name").(string)
-	log.Printf("[INFO] Reading Quota %s", entityName)
+	entity := quotaEntity(d)
+	log.Printf("[INFO] Reading Quota %s", Quota{Entity: entity})
 
-	foundQuota, err := c.DescribeQuota(entityType, entityName)
+	foundQuota, err := c.DescribeQuota(entity)
 	if err != nil {
 		log.Printf("[ERROR] Error getting quota %s from Kafka", err)
 		_, ok := err.(QuotaMissingError)
@@ -127,8 +157,12 @@ func quotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	}
 
 	errSet := errSetter{d: d}
-	errSet.Set("entity_name", foundQuota.EntityName)
-	errSet.Set("entity_type", foundQuota.EntityType)
+	if _, ok := d.GetOk("entity"); ok {
+		errSet.Set("entity", flattenQuotaEntity(foundQuota.Entity))
+	} else {
+		errSet.Set("entity_name", foundQuota.Entity[0].Name)
+		errSet.Set("entity_type", foundQuota.Entity[0].Type)
+	}
 	errSet.Set("config", configs)
 	if errSet.err != nil {
 		return diag.FromErr(errSet.err)
@@ -138,6 +172,39 @@ func quotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	return nil
 }
 
+func importQuota(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	entity, err := parseQuotaID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("failed importing resource; %w", err)
+	}
+
+	c := meta.(*LazyClient)
+	foundQuota, err := c.DescribeQuota(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed importing quota %s: %w", d.Id(), err)
+	}
+
+	configs := map[string]float64{}
+	for _, op := range foundQuota.Ops {
+		configs[op.Key] = op.Value
+	}
+
+	errSet := errSetter{d: d}
+	if len(entity) > 1 {
+		errSet.Set("entity", flattenQuotaEntity(entity))
+	} else {
+		errSet.Set("entity_name", entity[0].Name)
+		errSet.Set("entity_type", entity[0].Type)
+	}
+	errSet.Set("config", configs)
+	if errSet.err != nil {
+		return nil, errSet.err
+	}
+
+	d.SetId(foundQuota.ID())
+	return []*schema.ResourceData{d}, nil
+}
+
 func newQuota(d *schema.ResourceData, removeAll bool) Quota {
 	config := d.Get("config").(map[string]interface{})
 	ops := []QuotaOp{}
@@ -153,8 +220,80 @@ func newQuota(d *schema.ResourceData, removeAll bool) Quota {
 	}
 
 	return Quota{
-		EntityType: d.Get("entity_type").(string),
-		EntityName: d.Get("entity_name").(string),
-		Ops:        ops,
+		Entity: quotaEntity(d),
+		Ops:    ops,
 	}
 }
+
+// quotaEntity builds the entity either from the entity blocks or from the
+// single component entity_type/entity_name attributes.
+func quotaEntity(d quotaEntityGetter) []QuotaEntity {
+	if v, ok := d.GetOk("entity"); ok {
+		blocks := v.([]interface{})
+		entity := make([]QuotaEntity, 0, len(blocks))
+		for _, b := range blocks {
+			m, ok := b.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			entity = append(entity, QuotaEntity{
+				Type: m["type"].(string),
+				Name: m["name"].(string),
+			})
+		}
+		return entity
+	}
+
+	return []QuotaEntity{{
+		Type: d.Get("entity_type").(string),
+		Name: d.Get("entity_name").(string),
+	}}
+}
+
+// quotaEntityGetter is satisfied by both *schema.ResourceData and *schema.ResourceDiff
+type quotaEntityGetter interface {
+	Get(string) interface{}
+	GetOk(string) (interface{}, bool)
+}
+
+func flattenQuotaEntity(entity []QuotaEntity) []interface{} {
+	blocks := make([]interface{}, 0, len(entity))
+	for _, e := range entity {
+		blocks = append(blocks, map[string]interface{}{
+			"type": e.Type,
+			"name": e.Name,
+		})
+	}
+	return blocks
+}
+
+func quotaCustomDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	// The entity may not be known until apply when it is built from other resources
+	if !diff.NewValueKnown("entity") || !diff.NewValueKnown("entity_type") {
+		return nil
+	}
+
+	entity := quotaEntity(diff)
+	for _, e := range entity {
+		if e.Type == "" {
+			return nil
+		}
+	}
+
+	if err := validateQuotaEntity(entity); err != nil {
+		return err
+	}
+
+	if !diff.NewValueKnown("config") {
+		return nil
+	}
+
+	config := map[string]float64{}
+	for key, value := range diff.Get("config").(map[string]interface{}) {
+		if value, ok := value.(float64); ok {
+			config[key] = value
+		}
+	}
+
+	return errors.Join(validateQuotaConfig(entity, config)...)
+}
@@ -2,6 +2,8 @@ package kafka
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"testing"
 
 	uuid "github.com/hashicorp/go-uuid"
@@ -31,6 +33,34 @@ func TestAcc_BasicQuota(t *testing.T) {
 	})
 }
 
+func TestAcc_QuotaImport(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	quotaEntityName := fmt.Sprintf("quota1-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckQuotaDestroy,
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceQuota1, quotaEntityName, "4000000")),
+				Check:  testResourceQuota_initialCheck,
+			},
+			{
+				ResourceName:      "kafka_quota.test1",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s|client-id", quotaEntityName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAcc_QuotaConfigUpdate(t *testing.T) {
 	t.Parallel()
 	u, err := uuid.GenerateUUID()
@@ -93,6 +123,107 @@ func TestAcc_DefaultEntityQuotaConfigUpdate(t *testing.T) {
 	})
 }
 
+func TestAcc_CompositeEntityQuota(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	userName := fmt.Sprintf("quota-user-%s", u)
+	clientID := fmt.Sprintf("quota-client-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckQuotaDestroy,
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceQuotaComposite, userName, clientID, "4000000")),
+				Check: r.ComposeTestCheckFunc(
+					testResourceQuota_initialCheck,
+					r.TestCheckResourceAttr("kafka_quota.test1", "id", fmt.Sprintf("%s|user|%s|client-id", userName, clientID)),
+				),
+			},
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceQuotaComposite, userName, clientID, "3000000")),
+				Check:  testResourceQuota_updateCheck,
+			},
+			{
+				ResourceName:      "kafka_quota.test1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAcc_CompositeDefaultEntityQuota(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	userName := fmt.Sprintf("quota-user-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckQuotaDestroy,
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceQuotaCompositeDefaultClient, userName, "4000000")),
+				Check: r.ComposeTestCheckFunc(
+					testResourceQuota_initialCheck,
+					r.TestCheckResourceAttr("kafka_quota.test1", "id", fmt.Sprintf("%s|user|%s|client-id", userName, entityDefault)),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_QuotaInvalidConfig(t *testing.T) {
+	t.Parallel()
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []r.TestStep{
+			{
+				Config:      cfg(t, bs, testResourceQuotaInvalidKey),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("quota 'connection_creation_rate' can only be set on ip entities"),
+			},
+			{
+				Config:      cfg(t, bs, testResourceQuotaNegativeRate),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("quota 'producer_byte_rate' must not be negative"),
+			},
+		},
+	})
+}
+
+func testQuotaEntityFromAttributes(attrs map[string]string) []QuotaEntity {
+	count, err := strconv.Atoi(attrs["entity.#"])
+	if err != nil || count == 0 {
+		return []QuotaEntity{{
+			Type: attrs["entity_type"],
+			Name: attrs["entity_name"],
+		}}
+	}
+
+	entity := make([]QuotaEntity, 0, count)
+	for i := 0; i < count; i++ {
+		entity = append(entity, QuotaEntity{
+			Type: attrs[fmt.Sprintf("entity.%d.type", i)],
+			Name: attrs[fmt.Sprintf("entity.%d.name", i)],
+		})
+	}
+	return entity
+}
+
 func testResourceQuota_initialCheck(s *terraform.State) error {
 	resourceState := s.Modules[0].Resources["kafka_quota.test1"]
 	if resourceState == nil {
@@ -104,27 +235,23 @@ func testResourceQuota_initialCheck(s *terraform.State) error {
 		return fmt.Errorf("resource has no primary instance")
 	}
 
-	entityType := instanceState.Attributes["entity_type"]
-	entityName := instanceState.Attributes["entity_name"]
+	entity := testQuotaEntityFromAttributes(instanceState.Attributes)
 
 	client := testProvider.Meta().(*LazyClient)
-	quota, err := client.DescribeQuota(entityType, entityName)
+	quota, err := client.DescribeQuota(entity)
 	if err != nil {
 		return err
 	}
 
 	id := instanceState.ID
-	qID := fmt.Sprintf("%s|%s", entityName, entityType)
-	if entityName == "" {
-		qID = fmt.Sprintf("%s|%s", entityDefault, entityType)
-	}
+	qID := Quota{Entity: entity}.ID()
 
 	if id != qID {
 		return fmt.Errorf("id doesn't match for %s, got %s", id, qID)
 	}
 
 	if len(quota.Ops) != 2 {
-		return fmt.Errorf("expected configs for %s, got %v", quota.EntityName, quota.Ops)
+		return fmt.Errorf("expected configs for %s, got %v", quota, quota.Ops)
 	}
 
 	for _, q := range quota.Ops {
@@ -153,27 +280,23 @@ func testResourceQuota_updateCheck(s *terraform.State) error {
 		return fmt.Errorf("resource has no primary instance")
 	}
 
-	entityType := instanceState.Attributes["entity_type"]
-	entityName := instanceState.Attributes["entity_name"]
+	entity := testQuotaEntityFromAttributes(instanceState.Attributes)
 
 	client := testProvider.Meta().(*LazyClient)
-	quota, err := client.DescribeQuota(entityType, entityName)
+	quota, err := client.DescribeQuota(entity)
 	if err != nil {
 		return err
 	}
 
 	id := instanceState.ID
-	qID := fmt.Sprintf("%s|%s", entityName, entityType)
-	if entityName == "" {
-		qID = fmt.Sprintf("%s|%s", entityDefault, entityType)
-	}
+	qID := Quota{Entity: entity}.ID()
 
 	if id != qID {
 		return fmt.Errorf("id doesn't match for %s, got %s", id, qID)
 	}
 
 	if len(quota.Ops) != 2 {
-		return fmt.Errorf("expected configs for %s, got %v", quota.EntityName, quota.Ops)
+		return fmt.Errorf("expected configs for %s, got %v", quota, quota.Ops)
 	}
 
 	for _, q := range quota.Ops {
@@ -202,8 +325,7 @@ func testAccCheckQuotaDestroy(s *terraform.State) error {
 		return fmt.Errorf("resource has no primary instance")
 	}
 
-	entityType := instanceState.Attributes["entity_type"]
-	entityName := instanceState.Attributes["entity_name"]
+	entity := testQuotaEntityFromAttributes(instanceState.Attributes)
 
 	meta := testProvider.Meta()
 	if meta == nil {
@@ -211,7 +333,7 @@ func testAccCheckQuotaDestroy(s *terraform.State) error {
 	}
 
 	client := meta.(*LazyClient)
-	_, err := client.DescribeQuota(entityType, entityName)
+	_, err := client.DescribeQuota(entity)
 
 	if err == nil {
 		return fmt.Errorf("quota was found")
@@ -255,3 +377,64 @@ resource "kafka_quota" "test1" {
   }
 }
 `
+
+const testResourceQuotaComposite = `
+resource "kafka_quota" "test1" {
+  entity {
+    type = "user"
+    name = "%s"
+  }
+  entity {
+    type = "client-id"
+    name = "%s"
+  }
+  config = {
+    "consumer_byte_rate" = "%s"
+	"producer_byte_rate" = "2500000"
+  }
+}
+`
+
+const testResourceQuotaCompositeDefaultClient = `
+resource "kafka_quota" "test1" {
+  entity {
+    type = "user"
+    name = "%s"
+  }
+  entity {
+    type = "client-id"
+  }
+  config = {
+    "consumer_byte_rate" = "%s"
+	"producer_byte_rate" = "2500000"
+  }
+}
+`
+
+const testResourceQuotaInvalidKey = `
+resource "kafka_quota" "test1" {
+  entity_name = "alice"
+  entity_type = "user"
+  config = {
+    "connection_creation_rate" = "10"
+  }
+}
+`
+
+const testResourceQuotaNegativeRate = `
+resource "kafka_quota" "test1" {
+  entity_name = "203.0.113.1"
+  entity_type = "ip"
+  config = {
+    "connection_creation_rate" = "10"
+  }
+}
+
+resource "kafka_quota" "test2" {
+  entity_name = "alice"
+  entity_type = "user"
+  config = {
+    "producer_byte_rate" = "-1"
+  }
+}
+`
@@ -0,0 +1,486 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// serviceAccountParts are the parts of a service account, at least one of
+// which must be declared for the account to be found on read
+var serviceAccountParts = []string{"mechanism", "quota", "acl"}
+
+func kafkaServiceAccountResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: serviceAccountCreate,
+		ReadContext:   serviceAccountRead,
+		UpdateContext: serviceAccountUpdate,
+		DeleteContext: serviceAccountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importServiceAccount,
+		},
+		CustomizeDiff: serviceAccountCustomDiff,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateACLSetOperations,
+		},
+		Description: "Manages the SCRAM credentials, quotas and ACLs of a user together.",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDiagFunc(validation.StringIsNotWhiteSpace),
+				Description:      "The name of the user, without the User: prefix",
+			},
+			"principal": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The principal of the user, e.g. User:alice",
+			},
+			"mechanism": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: serviceAccountParts,
+				Description:  "The SCRAM mechanisms of the user, all generated from the same password. Leave out for users that authenticate another way, such as with a client certificate.",
+				Elem:         userScramMechanismResource(),
+			},
+			"password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				Description:   "The password of the SCRAM credentials (deprecated, use password_wo instead)",
+				Sensitive:     true,
+				ConflictsWith: []string{"password_wo"},
+			},
+			"password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				Description:   "The write-only password of the SCRAM credentials",
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"password"},
+			},
+			"password_wo_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Version identifier for the write-only password to track changes",
+			},
+			"quota": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				AtLeastOneOf: serviceAccountParts,
+				Description:  "The client quotas of the user, e.g. producer_byte_rate.",
+				Elem:         &schema.Schema{Type: schema.TypeFloat},
+			},
+			"acl": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: serviceAccountParts,
+				Description:  "The ACLs granted to or denied from the user",
+				Elem:         aclSetACLResource(),
+			},
+		},
+	}
+}
+
+// serviceAccountCreate creates the credentials first, so the user can log in
+// as soon as it is granted a quota and ACLs
+func serviceAccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	name := d.Get("name").(string)
+	principal := userPrincipalPrefix + name
+
+	userScramCredentials, err := serviceAccountCredentials(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(userScramCredentials) > 0 {
+		log.Printf("[INFO] Creating %d user scram credentials for %s", len(userScramCredentials), name)
+		if err := c.AlterUserScramCredentials(userScramCredentials, nil); err != nil {
+			log.Println("[ERROR] Failed to create service account credentials")
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(name)
+
+	quota := Quota{
+		Entity: serviceAccountQuotaEntity(name),
+		Ops:    serviceAccountQuotaOps(nil, d.Get("quota").(map[string]interface{})),
+	}
+	if len(quota.Ops) > 0 {
+		log.Printf("[INFO] Creating Quota %s", quota)
+		if err := c.AlterQuota(quota); err != nil {
+			log.Println("[ERROR] Failed to create service account quota")
+			return diag.FromErr(err)
+		}
+
+		stateConf := &retry.StateChangeConf{
+			Pending:      []string{"Pending"},
+			Target:       []string{"Created"},
+			Refresh:      quotaCreatedFunc(c, quota),
+			Timeout:      time.Duration(c.Config.Timeout) * time.Second,
+			Delay:        1 * time.Second,
+			PollInterval: 2 * time.Second,
+		}
+		if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+			return diag.FromErr(fmt.Errorf("error waiting for quota (%s) to be created: %s", quota.ID(), err))
+		}
+	}
+
+	acls := aclSetInfo(principal, d.Get("acl").(*schema.Set))
+	log.Printf("[INFO] Creating %d ACLs for %s", len(acls), principal)
+	if err := c.CreateACLs(acls); err != nil {
+		log.Println("[ERROR] Failed to create service account ACLs")
+		return diag.FromErr(err)
+	}
+	if len(acls) > 0 {
+		if err := waitForACLSet(ctx, c, principal, acls, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("principal", principal); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func serviceAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	name := d.Get("name").(string)
+	principal := userPrincipalPrefix + name
+	log.Printf("[INFO] Reading service account %s", name)
+
+	// Only the declared mechanisms, quotas and ACLs are read, so the rest can
+	// be managed by kafka_user_scram_credential, kafka_quota and kafka_acl
+	declaredMechanisms := map[string]bool{}
+	for _, userScramCredential := range userScramMechanismSet(name, d.Get("mechanism").(*schema.Set)) {
+		declaredMechanisms[userScramCredential.Mechanism.String()] = true
+	}
+	mechanisms, err := serviceAccountMechanisms(c, name, declaredMechanisms)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	quotas, err := serviceAccountQuotas(c, name, d.Get("quota").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	found, err := c.FilterACLs(StringlyTypedACL{ACL: ACL{Principal: principal}})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	managed := map[string]bool{}
+	for _, a := range aclSetInfo(principal, d.Get("acl").(*schema.Set)) {
+		managed[a.String()] = true
+	}
+	acls := []StringlyTypedACL{}
+	for _, a := range found {
+		if managed[a.String()] {
+			acls = append(acls, a)
+		}
+	}
+
+	// An account that declares nothing, such as one with an empty quota map,
+	// cannot be told apart from a deleted one, so it is kept
+	declared := len(declaredMechanisms) > 0 || len(d.Get("quota").(map[string]interface{})) > 0 || len(managed) > 0
+	if declared && len(mechanisms) == 0 && len(quotas) == 0 && len(acls) == 0 {
+		log.Printf("[INFO] Did not find service account %s", name)
+		d.SetId("")
+		return nil
+	}
+
+	errSet := errSetter{d: d}
+	errSet.Set("name", name)
+	errSet.Set("principal", principal)
+	errSet.Set("mechanism", mechanisms)
+	errSet.Set("quota", quotas)
+	errSet.Set("acl", flattenACLSet(acls))
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	return nil
+}
+
+// serviceAccountUpdate applies changes in the same order as create:
+// credentials, then quotas, then ACLs
+func serviceAccountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	name := d.Get("name").(string)
+	principal := userPrincipalPrefix + name
+
+	// Keep the old values in state until every step succeeds, so a failed
+	// credential or quota change is retried
+	d.Partial(true)
+
+	if d.HasChanges("password", "password_wo", "password_wo_version", "mechanism") {
+		userScramCredentials, err := serviceAccountCredentials(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		o, _ := d.GetChange("mechanism")
+		deleted := []UserScramCredential{}
+		for _, old := range userScramMechanismSet(name, o.(*schema.Set)) {
+			if !slices.ContainsFunc(userScramCredentials, func(u UserScramCredential) bool { return u.Mechanism == old.Mechanism }) {
+				deleted = append(deleted, old)
+			}
+		}
+
+		log.Printf("[INFO] Updating user scram credentials for %s", name)
+		if err := c.AlterUserScramCredentials(userScramCredentials, deleted); err != nil {
+			log.Println("[ERROR] Failed to update service account credentials")
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("quota") {
+		o, n := d.GetChange("quota")
+		quota := Quota{
+			Entity: serviceAccountQuotaEntity(name),
+			Ops:    serviceAccountQuotaOps(o.(map[string]interface{}), n.(map[string]interface{})),
+		}
+		log.Printf("[INFO] Updating Quota %s", quota)
+		if err := c.AlterQuota(quota); err != nil {
+			log.Println("[ERROR] Failed to update service account quota")
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("acl") {
+		o, n := d.GetChange("acl")
+		oldSet := o.(*schema.Set)
+		newSet := n.(*schema.Set)
+		added := aclSetInfo(principal, newSet.Difference(oldSet))
+		removed := aclSetInfo(principal, oldSet.Difference(newSet))
+
+		// Credentials and quotas are applied by now, so only the ACLs of either
+		// set still on the broker are recorded, as kafka_acl_set does
+		failed := func(err error) diag.Diagnostics {
+			d.Partial(false)
+			recordAppliedACLSet(c, d, principal, aclSetInfo(principal, oldSet.Union(newSet)))
+			return diag.FromErr(err)
+		}
+
+		log.Printf("[INFO] Updating ACLs for %s: adding %d, removing %d", principal, len(added), len(removed))
+		if err := c.CreateACLs(added); err != nil {
+			return failed(err)
+		}
+		if err := c.DeleteACLs(removed); err != nil {
+			return failed(err)
+		}
+		if err := waitForACLSet(ctx, c, principal, added, removed); err != nil {
+			return failed(err)
+		}
+	}
+
+	d.Partial(false)
+	return nil
+}
+
+// serviceAccountDelete runs create in reverse, revoking ACLs and quotas before
+// the credentials they apply to are deleted
+func serviceAccountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*LazyClient)
+	name := d.Get("name").(string)
+	principal := userPrincipalPrefix + name
+
+	acls := aclSetInfo(principal, d.Get("acl").(*schema.Set))
+	log.Printf("[INFO] Deleting %d ACLs for %s", len(acls), principal)
+	if err := c.DeleteACLs(acls); err != nil {
+		return diag.FromErr(err)
+	}
+	if len(acls) > 0 {
+		if err := waitForACLSet(ctx, c, principal, nil, acls); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	quota := Quota{
+		Entity: serviceAccountQuotaEntity(name),
+		Ops:    serviceAccountQuotaOps(d.Get("quota").(map[string]interface{}), nil),
+	}
+	if len(quota.Ops) > 0 {
+		log.Printf("[INFO] Deleting quota %s", quota)
+		if err := c.AlterQuota(quota); err != nil {
+			log.Println("[ERROR] Failed to delete service account quota")
+			return diag.FromErr(err)
+		}
+	}
+
+	// Deleting a mechanism the user does not have fails the whole request, so
+	// only delete the ones that are left
+	existing, err := c.DescribeUserScramCredentials(name)
+	if err != nil {
+		if _, ok := err.(UserScramCredentialMissingError); ok {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	userScramCredentials := []UserScramCredential{}
+	for _, userScramCredential := range userScramMechanismSet(name, d.Get("mechanism").(*schema.Set)) {
+		if slices.ContainsFunc(existing, func(u UserScramCredential) bool { return u.Mechanism == userScramCredential.Mechanism }) {
+			userScramCredentials = append(userScramCredentials, userScramCredential)
+		}
+	}
+	if len(userScramCredentials) > 0 {
+		log.Printf("[INFO] Deleting %d user scram credentials for %s", len(userScramCredentials), name)
+		if err := c.AlterUserScramCredentials(nil, userScramCredentials); err != nil {
+			log.Println("[ERROR] Failed to delete service account credentials")
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// importServiceAccount adopts every SCRAM mechanism, quota and ACL of the
+// user, since there is nothing declared to match them against yet
+func importServiceAccount(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*LazyClient)
+	name := d.Id()
+
+	mechanisms, err := serviceAccountMechanisms(c, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed importing service account %s: %w", name, err)
+	}
+	quotas, err := serviceAccountQuotas(c, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed importing service account %s: %w", name, err)
+	}
+	found, err := c.FilterACLs(StringlyTypedACL{ACL: ACL{Principal: userPrincipalPrefix + name}})
+	if err != nil {
+		return nil, fmt.Errorf("failed importing service account %s: %w", name, err)
+	}
+
+	errSet := errSetter{d: d}
+	errSet.Set("name", name)
+	errSet.Set("mechanism", mechanisms)
+	errSet.Set("quota", quotas)
+	errSet.Set("acl", flattenACLSet(found))
+	if errSet.err != nil {
+		return nil, errSet.err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// serviceAccountMechanisms returns the SCRAM mechanisms of the user in
+// declared, or all of them when declared is nil
+func serviceAccountMechanisms(c *LazyClient, name string, declared map[string]bool) ([]interface{}, error) {
+	userScramCredentials, err := c.DescribeUserScramCredentials(name)
+	if err != nil {
+		if _, ok := err.(UserScramCredentialMissingError); !ok {
+			return nil, err
+		}
+	}
+
+	mechanisms := make([]interface{}, 0, len(userScramCredentials))
+	for _, userScramCredential := range userScramCredentials {
+		mechanism := userScramCredential.Mechanism.String()
+		if declared != nil && !declared[mechanism] {
+			continue
+		}
+		mechanisms = append(mechanisms, map[string]interface{}{
+			"name":       mechanism,
+			"iterations": int(userScramCredential.Iterations),
+		})
+	}
+	return mechanisms, nil
+}
+
+// serviceAccountQuotas returns the quotas of the user with a key in declared,
+// or all of them when declared is nil
+func serviceAccountQuotas(c *LazyClient, name string, declared map[string]interface{}) (map[string]float64, error) {
+	quotas := map[string]float64{}
+	found, err := c.DescribeQuota(serviceAccountQuotaEntity(name))
+	if err != nil {
+		if _, ok := err.(QuotaMissingError); ok {
+			return quotas, nil
+		}
+		return nil, err
+	}
+
+	for _, op := range found.Ops {
+		if _, ok := declared[op.Key]; declared != nil && !ok {
+			continue
+		}
+		quotas[op.Key] = op.Value
+	}
+	return quotas, nil
+}
+
+func serviceAccountCustomDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	errs := []error{}
+	if set, ok := diff.Get("mechanism").(*schema.Set); ok && set.Len() > 0 {
+		if err := validatePasswordFields(ctx, diff, v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if diff.NewValueKnown("name") && diff.NewValueKnown("quota") {
+		config := map[string]float64{}
+		for key, value := range diff.Get("quota").(map[string]interface{}) {
+			if value, ok := value.(float64); ok {
+				config[key] = value
+			}
+		}
+		errs = append(errs, validateQuotaConfig(serviceAccountQuotaEntity(diff.Get("name").(string)), config)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// serviceAccountCredentials returns a credential with the password for every
+// mechanism block, or none when the user has no SCRAM credentials
+func serviceAccountCredentials(d *schema.ResourceData) ([]UserScramCredential, error) {
+	userScramCredentials := userScramMechanismSet(d.Get("name").(string), d.Get("mechanism").(*schema.Set))
+	if len(userScramCredentials) == 0 {
+		return nil, nil
+	}
+
+	password, err := getPasswordFromConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	for i := range userScramCredentials {
+		userScramCredentials[i].Password = []byte(password)
+	}
+	return userScramCredentials, nil
+}
+
+func serviceAccountQuotaEntity(name string) []QuotaEntity {
+	return []QuotaEntity{{Type: "user", Name: name}}
+}
+
+// serviceAccountQuotaOps sets every quota in updated and removes the quotas
+// only in old
+func serviceAccountQuotaOps(old map[string]interface{}, updated map[string]interface{}) []QuotaOp {
+	ops := []QuotaOp{}
+	for key, value := range updated {
+		if value, ok := value.(float64); ok {
+			ops = append(ops, QuotaOp{Key: key, Value: value})
+		}
+	}
+	for key := range old {
+		if _, ok := updated[key]; !ok {
+			ops = append(ops, QuotaOp{Key: key, Remove: true})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Key < ops[j].Key })
+	return ops
+}
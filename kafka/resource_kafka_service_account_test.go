@@ -0,0 +1,159 @@
+package kafka
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	uuid "github.com/hashicorp/go-uuid"
+	r "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAcc_ServiceAccount(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := fmt.Sprintf("service-account-%s", u)
+	topicName := fmt.Sprintf("syslog-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      func(s *terraform.State) error { return testAccCheckServiceAccountDestroy(name) },
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceServiceAccount_initialConfig, name, topicName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_service_account.test", "id", name),
+					r.TestCheckResourceAttr("kafka_service_account.test", "principal", "User:"+name),
+					r.TestCheckResourceAttr("kafka_service_account.test", "quota.producer_byte_rate", "4000000"),
+					r.TestCheckResourceAttr("kafka_service_account.test", "acl.#", "1"),
+					testAccCheckUserScramMechanisms(name, "SCRAM-SHA-256"),
+					testAccCheckACLSetCount("User:"+name, 1),
+				),
+			},
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceServiceAccount_updateConfig, name, topicName)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_service_account.test", "quota.%", "1"),
+					r.TestCheckResourceAttr("kafka_service_account.test", "quota.consumer_byte_rate", "5000000"),
+					r.TestCheckResourceAttr("kafka_service_account.test", "acl.#", "2"),
+					testAccCheckUserScramMechanisms(name, "SCRAM-SHA-512"),
+					testAccCheckACLSetCount("User:"+name, 2),
+				),
+			},
+			{
+				ResourceName:            "kafka_service_account.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func testAccCheckServiceAccountDestroy(name string) error {
+	client := testProvider.Meta().(*LazyClient)
+
+	if err := testAccCheckACLSetDestroy("User:" + name); err != nil {
+		return err
+	}
+
+	if _, err := client.DescribeQuota(serviceAccountQuotaEntity(name)); err == nil {
+		return fmt.Errorf("quota of %s still exists", name)
+	} else if _, ok := err.(QuotaMissingError); !ok {
+		return err
+	}
+
+	userScramCredentials, err := client.DescribeUserScramCredentials(name)
+	if _, ok := err.(UserScramCredentialMissingError); ok {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(userScramCredentials) > 0 {
+		return fmt.Errorf("user scram credentials of %s still exist: %v", name, userScramCredentials)
+	}
+	return nil
+}
+
+func TestServiceAccountQuotaOps(t *testing.T) {
+	old := map[string]interface{}{
+		"producer_byte_rate": 4000000.0,
+		"request_percentage": 50.0,
+	}
+	updated := map[string]interface{}{
+		"producer_byte_rate": 2000000.0,
+		"consumer_byte_rate": 5000000.0,
+	}
+
+	expected := []QuotaOp{
+		{Key: "consumer_byte_rate", Value: 5000000},
+		{Key: "producer_byte_rate", Value: 2000000},
+		{Key: "request_percentage", Remove: true},
+	}
+	if ops := serviceAccountQuotaOps(old, updated); !reflect.DeepEqual(ops, expected) {
+		t.Errorf("expected %v, got %v", expected, ops)
+	}
+
+	removeAll := []QuotaOp{
+		{Key: "producer_byte_rate", Remove: true},
+		{Key: "request_percentage", Remove: true},
+	}
+	if ops := serviceAccountQuotaOps(old, nil); !reflect.DeepEqual(ops, removeAll) {
+		t.Errorf("expected %v, got %v", removeAll, ops)
+	}
+}
+
+const testResourceServiceAccount_initialConfig = `
+resource "kafka_service_account" "test" {
+  name     = "%s"
+  password = "test"
+
+  mechanism {
+    name = "SCRAM-SHA-256"
+  }
+
+  quota = {
+    "producer_byte_rate" = 4000000
+  }
+
+  acl {
+    resource_name = "%[2]s"
+    resource_type = "Topic"
+    acl_operation = "Write"
+  }
+}
+`
+
+const testResourceServiceAccount_updateConfig = `
+resource "kafka_service_account" "test" {
+  name     = "%s"
+  password = "test"
+
+  mechanism {
+    name = "SCRAM-SHA-512"
+  }
+
+  quota = {
+    "consumer_byte_rate" = 5000000
+  }
+
+  acl {
+    resource_name = "%[2]s"
+    resource_type = "Topic"
+    acl_operation = "Read"
+  }
+
+  acl {
+    resource_name = "%[2]s"
+    resource_type = "Topic"
+    acl_operation = "Describe"
+  }
+}
+`
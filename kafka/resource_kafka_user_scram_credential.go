@@ -4,16 +4,22 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const defaultIterations int32 = 4096
 
+var scramMechanisms = []string{sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512}
+
 // getPasswordFromConfig extracts password from either 'password' or 'password_wo' field,
 // handling write-only fields by accessing raw config when necessary
 func getPasswordFromConfig(d interface{}) (string, error) {
@@ -111,10 +117,17 @@ func kafkaUserScramCredentialResource() *schema.Resource {
 			},
 			"scram_mechanism": {
 				Type:             schema.TypeString,
-				Required:         true,
-				ForceNew:         true,
-				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice([]string{sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512}, false)),
-				Description:      "The SCRAM mechanism used to generate the credential (SCRAM-SHA-256, SCRAM-SHA-512)",
+				Optional:         true,
+				ExactlyOneOf:     []string{"scram_mechanism", "mechanism"},
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice(scramMechanisms, false)),
+				Description:      "The SCRAM mechanism used to generate the credential (SCRAM-SHA-256, SCRAM-SHA-512). Changing it replaces the credential in a single request. Use mechanism to manage several mechanisms.",
+			},
+			"mechanism": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ExactlyOneOf: []string{"scram_mechanism", "mechanism"},
+				Description:  "The SCRAM mechanisms of the user, all generated from the same password and upserted together. Mechanisms of the user not listed are deleted.",
+				Elem:         userScramMechanismResource(),
 			},
 			"scram_iterations": {
 				Type:         schema.TypeInt,
@@ -122,7 +135,7 @@ func kafkaUserScramCredentialResource() *schema.Resource {
 				ForceNew:     false,
 				Default:      defaultIterations,
 				ValidateFunc: validation.IntAtLeast(4096),
-				Description:  "The number of SCRAM iterations used when generating the credential",
+				Description:  "The number of SCRAM iterations used when generating the scram_mechanism credential",
 			},
 			"password": {
 				Type:          schema.TypeString,
@@ -143,6 +156,18 @@ func kafkaUserScramCredentialResource() *schema.Resource {
 				WriteOnly:     true,
 				ConflictsWith: []string{"password"},
 			},
+			"verify_login": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Verify the credential after creating or updating it by logging in as the user with SCRAM, retrying until the timeout while it propagates to the brokers",
+			},
+			"verify_login_bootstrap_servers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The SASL listeners to log in to when verify_login is set. Defaults to every broker on the provider's listener.",
+			},
 			"password_wo_version": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -153,9 +178,37 @@ func kafkaUserScramCredentialResource() *schema.Resource {
 	}
 }
 
+// userScramMechanismResource is the schema of a mechanism block
+func userScramMechanismResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDiagFunc(validation.StringInSlice(scramMechanisms, false)),
+				Description:      "The SCRAM mechanism (SCRAM-SHA-256, SCRAM-SHA-512)",
+			},
+			"iterations": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          defaultIterations,
+				ValidateDiagFunc: validateDiagFunc(validation.IntAtLeast(4096)),
+				Description:      "The number of SCRAM iterations used when generating the credential",
+			},
+		},
+	}
+}
+
 func importSCRAM(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "|")
-	if len(parts) == 2 {
+	if len(parts) == 1 {
+		// username: every mechanism of the user, managed with mechanism blocks
+		errSet := errSetter{d: d}
+		errSet.Set("username", parts[0])
+		if errSet.err != nil {
+			return nil, errSet.err
+		}
+	} else if len(parts) == 2 {
 		// New format: username|scram_mechanism (for write-only passwords)
 		errSet := errSetter{d: d}
 		errSet.Set("username", parts[0])
@@ -174,7 +227,11 @@ func importSCRAM(ctx context.Context, d *schema.ResourceData, m interface{}) ([]
 			return nil, errSet.err
 		}
 	} else {
-		return nil, fmt.Errorf("failed importing resource; expected format is username|scram_mechanism (for write-only passwords) or username|scram_mechanism|password (legacy) - got %v segments instead of 2 or 3", len(parts))
+		return nil, fmt.Errorf("failed importing resource; expected format is username (for mechanism blocks), username|scram_mechanism (for write-only passwords) or username|scram_mechanism|password (legacy) - got %v segments instead of 1, 2 or 3", len(parts))
+	}
+
+	if err := d.Set("verify_login", false); err != nil {
+		return nil, err
 	}
 
 	return []*schema.ResourceData{d}, nil
@@ -183,18 +240,22 @@ func importSCRAM(ctx context.Context, d *schema.ResourceData, m interface{}) ([]
 func userScramCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Creating user scram credential")
 	c := meta.(*LazyClient)
-	userScramCredential, err := parseUserScramCredential(d)
+	userScramCredentials, err := parseUserScramCredentials(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = c.UpsertUserScramCredential(userScramCredential)
+	err = c.AlterUserScramCredentials(userScramCredentials, nil)
 	if err != nil {
 		log.Println("[ERROR] Failed to create user scram credential")
 		return diag.FromErr(err)
 	}
 
-	d.SetId(userScramCredential.ID())
+	d.SetId(userScramCredentialID(d))
+
+	if err := verifyUserScramLogin(ctx, d, c, userScramCredentials); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -203,6 +264,9 @@ func userScramCredentialRead(ctx context.Context, d *schema.ResourceData, meta i
 	c := meta.(*LazyClient)
 	username := d.Get("username").(string)
 	mechanism := d.Get("scram_mechanism").(string)
+	if mechanism == "" {
+		return userScramCredentialsRead(d, c, username)
+	}
 
 	userScramCredential, err := c.DescribeUserScramCredential(username, mechanism)
 	if err != nil {
@@ -230,22 +294,76 @@ func userScramCredentialRead(ctx context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
+// userScramCredentialsRead reads back every mechanism of a user managed with
+// mechanism blocks
+func userScramCredentialsRead(d *schema.ResourceData, c *LazyClient, username string) diag.Diagnostics {
+	userScramCredentials, err := c.DescribeUserScramCredentials(username)
+	if err != nil {
+		log.Printf("[ERROR] Error getting user scram credentials %s from Kafka", err)
+		if _, ok := err.(UserScramCredentialMissingError); ok {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if len(userScramCredentials) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	mechanisms := make([]interface{}, 0, len(userScramCredentials))
+	for _, userScramCredential := range userScramCredentials {
+		mechanisms = append(mechanisms, map[string]interface{}{
+			"name":       userScramCredential.Mechanism.String(),
+			"iterations": int(userScramCredential.Iterations),
+		})
+	}
+
+	errSet := errSetter{d: d}
+	errSet.Set("username", username)
+	errSet.Set("mechanism", mechanisms)
+	if errSet.err != nil {
+		return diag.FromErr(errSet.err)
+	}
+
+	return nil
+}
+
 func userScramCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Updating user scram credential")
 	c := meta.(*LazyClient)
 
-	// Only update if password-related fields have changed
-	if d.HasChange("password") || d.HasChange("password_wo") || d.HasChange("password_wo_version") || d.HasChange("scram_iterations") {
-		userScramCredential, err := parseUserScramCredential(d)
+	// Only update if password-related fields or mechanisms have changed
+	if d.HasChanges("password", "password_wo", "password_wo_version", "scram_iterations", "scram_mechanism", "mechanism") {
+		userScramCredentials, err := parseUserScramCredentials(d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		err = c.UpsertUserScramCredential(userScramCredential)
+		// Removed mechanisms are deleted in the same request that upserts the
+		// new ones, so the user always has a credential
+		oldMechanisms := userScramMechanisms(d.Get("username").(string), func(key string) interface{} {
+			o, _ := d.GetChange(key)
+			return o
+		})
+		deleted := []UserScramCredential{}
+		for _, old := range oldMechanisms {
+			if !slices.ContainsFunc(userScramCredentials, func(u UserScramCredential) bool { return u.Mechanism == old.Mechanism }) {
+				deleted = append(deleted, old)
+			}
+		}
+
+		err = c.AlterUserScramCredentials(userScramCredentials, deleted)
 		if err != nil {
 			log.Println("[ERROR] Failed to update user scram credential")
 			return diag.FromErr(err)
 		}
+
+		d.SetId(userScramCredentialID(d))
+
+		if err := verifyUserScramLogin(ctx, d, c, userScramCredentials); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return nil
@@ -256,14 +374,9 @@ func userScramCredentialDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	c := meta.(*LazyClient)
 
-	scram_mechanism_string := d.Get("scram_mechanism").(string)
-	mechanism := convertedScramMechanism(scram_mechanism_string)
-	userScramCredential := UserScramCredential{
-		Name:      d.Get("username").(string),
-		Mechanism: mechanism,
-	}
+	userScramCredentials := userScramMechanisms(d.Get("username").(string), d.Get)
 
-	err := c.DeleteUserScramCredential(userScramCredential)
+	err := c.AlterUserScramCredentials(nil, userScramCredentials)
 	if err != nil {
 		log.Println("[ERROR] Failed to delete user scram credential")
 		return diag.FromErr(err)
@@ -289,6 +402,111 @@ func parseUserScramCredential(d *schema.ResourceData) (UserScramCredential, erro
 	}, nil
 }
 
+// verifyUserScramLogin logs in with every credential when verify_login is set,
+// retrying until the provider timeout since new credentials reach the brokers
+// eventually
+func verifyUserScramLogin(ctx context.Context, d *schema.ResourceData, c *LazyClient, userScramCredentials []UserScramCredential) error {
+	if !d.Get("verify_login").(bool) {
+		return nil
+	}
+	addrs := stringList(d.Get("verify_login_bootstrap_servers"))
+
+	var lastErr error
+	refresh := func() (interface{}, string, error) {
+		for _, userScramCredential := range userScramCredentials {
+			if lastErr = c.VerifyUserScramLogin(userScramCredential, addrs); lastErr != nil {
+				log.Printf("[DEBUG] Login with user scram credential %s failed: %v", userScramCredential, lastErr)
+				return userScramCredentials, "Pending", nil
+			}
+		}
+		return userScramCredentials, "Ready", nil
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{"Pending"},
+		Target:       []string{"Ready"},
+		Refresh:      refresh,
+		Timeout:      time.Duration(c.Config.Timeout) * time.Second,
+		PollInterval: time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		if lastErr != nil {
+			return fmt.Errorf("failed to log in as %s: %w", d.Get("username").(string), lastErr)
+		}
+		return fmt.Errorf("failed to log in as %s: %s", d.Get("username").(string), err)
+	}
+	return nil
+}
+
+// parseUserScramCredentials returns a credential with the password for every
+// configured mechanism
+func parseUserScramCredentials(d *schema.ResourceData) ([]UserScramCredential, error) {
+	if d.Get("scram_mechanism").(string) != "" {
+		userScramCredential, err := parseUserScramCredential(d)
+		if err != nil {
+			return nil, err
+		}
+		return []UserScramCredential{userScramCredential}, nil
+	}
+
+	password, err := getPasswordFromConfig(d)
+	if err != nil {
+		return nil, err
+	}
+
+	userScramCredentials := userScramMechanisms(d.Get("username").(string), d.Get)
+	for i := range userScramCredentials {
+		userScramCredentials[i].Password = []byte(password)
+	}
+	return userScramCredentials, nil
+}
+
+// userScramMechanisms returns the credentials of either scram_mechanism or the
+// mechanism blocks, without passwords, reading attributes with get
+func userScramMechanisms(username string, get func(string) interface{}) []UserScramCredential {
+	if mechanism := get("scram_mechanism").(string); mechanism != "" {
+		return []UserScramCredential{{
+			Name:       username,
+			Mechanism:  convertedScramMechanism(mechanism),
+			Iterations: int32(get("scram_iterations").(int)),
+		}}
+	}
+
+	set, _ := get("mechanism").(*schema.Set)
+	return userScramMechanismSet(username, set)
+}
+
+// userScramMechanismSet returns the credentials of the mechanism blocks in
+// set, without passwords, sorted by mechanism
+func userScramMechanismSet(username string, set *schema.Set) []UserScramCredential {
+	userScramCredentials := []UserScramCredential{}
+	if set != nil {
+		for _, v := range set.List() {
+			m := v.(map[string]interface{})
+			userScramCredentials = append(userScramCredentials, UserScramCredential{
+				Name:       username,
+				Mechanism:  convertedScramMechanism(m["name"].(string)),
+				Iterations: int32(m["iterations"].(int)),
+			})
+		}
+	}
+	sort.Slice(userScramCredentials, func(i, j int) bool {
+		return userScramCredentials[i].Mechanism < userScramCredentials[j].Mechanism
+	})
+	return userScramCredentials
+}
+
+// userScramCredentialID is username|scram_mechanism, or the username when
+// the mechanism blocks are used
+func userScramCredentialID(d *schema.ResourceData) string {
+	username := d.Get("username").(string)
+	if mechanism := d.Get("scram_mechanism").(string); mechanism != "" {
+		return strings.Join([]string{username, mechanism}, "|")
+	}
+	return username
+}
+
 func convertedScramMechanism(scram_mechanism_string string) sarama.ScramMechanismType {
 	switch scram_mechanism_string {
 	case sarama.SCRAM_MECHANISM_SHA_256.String():
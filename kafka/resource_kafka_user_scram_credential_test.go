@@ -107,6 +107,75 @@ func TestAcc_UserScramCredentialConfigUpdate(t *testing.T) {
 	})
 }
 
+func TestAcc_UserScramCredentialMechanismMigration(t *testing.T) {
+	t.Parallel()
+	u, err := uuid.GenerateUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	username := fmt.Sprintf("test-%s", u)
+	bs := testBootstrapServers[0]
+
+	r.Test(t, r.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckUserScramCredentialDestroy,
+		Steps: []r.TestStep{
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceUserScramCredential_SHA256, username)),
+				Check:  testAccCheckUserScramMechanisms(username, "SCRAM-SHA-256"),
+			},
+			{
+				// Switching scram_mechanism upserts the new mechanism and
+				// deletes the old one in a single request
+				Config: cfg(t, bs, fmt.Sprintf(testResourceUserScramCredential_SHA512, username)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_user_scram_credential.test", "id", username+"|SCRAM-SHA-512"),
+					testAccCheckUserScramMechanisms(username, "SCRAM-SHA-512"),
+				),
+			},
+			{
+				Config: cfg(t, bs, fmt.Sprintf(testResourceUserScramCredential_Mechanisms, username)),
+				Check: r.ComposeTestCheckFunc(
+					r.TestCheckResourceAttr("kafka_user_scram_credential.test", "id", username),
+					r.TestCheckResourceAttr("kafka_user_scram_credential.test", "mechanism.#", "2"),
+					r.TestCheckTypeSetElemNestedAttrs("kafka_user_scram_credential.test", "mechanism.*", map[string]string{
+						"name":       "SCRAM-SHA-256",
+						"iterations": "8192",
+					}),
+					testAccCheckUserScramMechanisms(username, "SCRAM-SHA-256", "SCRAM-SHA-512"),
+				),
+			},
+			{
+				ResourceName:            "kafka_user_scram_credential.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "scram_iterations"},
+			},
+		},
+	})
+}
+
+func testAccCheckUserScramMechanisms(username string, expected ...string) r.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*LazyClient)
+		userScramCredentials, err := client.DescribeUserScramCredentials(username)
+		if err != nil {
+			return err
+		}
+
+		mechanisms := []string{}
+		for _, userScramCredential := range userScramCredentials {
+			mechanisms = append(mechanisms, userScramCredential.Mechanism.String())
+		}
+		if strings.Join(mechanisms, ",") != strings.Join(expected, ",") {
+			return fmt.Errorf("expected mechanisms %v for %s, got %v", expected, username, mechanisms)
+		}
+		return nil
+	}
+}
+
 func TestAcc_UserScramCredentialWriteOnly(t *testing.T) {
 	t.Parallel()
 	u, err := uuid.GenerateUUID()
@@ -311,7 +380,12 @@ func testAccCheckUserScramCredentialDestroy(s *terraform.State) error {
 	}
 
 	client := meta.(*LazyClient)
-	_, err := client.DescribeUserScramCredential(username, mechanism)
+	var err error
+	if mechanism == "" {
+		_, err = client.DescribeUserScramCredentials(username)
+	} else {
+		_, err = client.DescribeUserScramCredential(username, mechanism)
+	}
 
 	if _, ok := err.(UserScramCredentialMissingError); !ok {
 		if err == nil {
@@ -339,6 +413,22 @@ resource "kafka_user_scram_credential" "test" {
   password               = "test"
 }
 `
+const testResourceUserScramCredential_Mechanisms = `
+resource "kafka_user_scram_credential" "test" {
+  username               = "%s"
+  password               = "test"
+
+  mechanism {
+    name       = "SCRAM-SHA-256"
+    iterations = 8192
+  }
+
+  mechanism {
+    name = "SCRAM-SHA-512"
+  }
+}
+`
+
 const testResourceUserScramCredential_WithIterations = `
 resource "kafka_user_scram_credential" "test" {
   username               = "%s"
@@ -563,3 +653,43 @@ func Test_parseUserScramCredential_ValidPasswordWo(t *testing.T) {
 		t.Errorf("expected iterations 8192, got %d", credential.Iterations)
 	}
 }
+
+func Test_parseUserScramCredentials_Mechanisms(t *testing.T) {
+	d := kafkaUserScramCredentialResource().TestResourceData()
+
+	if err := d.Set("username", "testuser"); err != nil {
+		t.Fatalf("failed to set username: %v", err)
+	}
+	if err := d.Set("password", "valid-password"); err != nil {
+		t.Fatalf("failed to set password: %v", err)
+	}
+	mechanisms := []interface{}{
+		map[string]interface{}{"name": "SCRAM-SHA-512", "iterations": 4096},
+		map[string]interface{}{"name": "SCRAM-SHA-256", "iterations": 8192},
+	}
+	if err := d.Set("mechanism", mechanisms); err != nil {
+		t.Fatalf("failed to set mechanism: %v", err)
+	}
+
+	credentials, err := parseUserScramCredentials(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"testuser|SCRAM-SHA-256|8192", "testuser|SCRAM-SHA-512|4096"}
+	if len(credentials) != len(expected) {
+		t.Fatalf("expected %d credentials, got %v", len(expected), credentials)
+	}
+	for i, credential := range credentials {
+		if credential.String() != expected[i] {
+			t.Errorf("expected credential %q, got %q", expected[i], credential.String())
+		}
+		if string(credential.Password) != "valid-password" {
+			t.Errorf("expected every credential to use the password, got %q", string(credential.Password))
+		}
+	}
+
+	if id := userScramCredentialID(d); id != "testuser" {
+		t.Errorf("expected ID 'testuser', got %q", id)
+	}
+}